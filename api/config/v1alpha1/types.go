@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// ManagerConfiguration is the configuration of the controller manager, which
+// is loaded from the file passed with --config. Each setting has a flag of
+// the same name, which overrides the value in the file.
+type ManagerConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ClusterResourceNamespace is the namespace of the Secrets referenced by
+	// SampleClusterIssuers. Defaults to the namespace the controller runs in.
+	// Flag: --cluster-resource-namespace.
+	// +optional
+	ClusterResourceNamespace string `json:"clusterResourceNamespace,omitempty"`
+
+	// Metrics configures the metrics server.
+	// +optional
+	Metrics MetricsConfiguration `json:"metrics,omitempty"`
+
+	// Webhook configures the webhook server.
+	// +optional
+	Webhook WebhookConfiguration `json:"webhook,omitempty"`
+
+	// HealthProbeBindAddress is the address to which the probe endpoint
+	// binds. Defaults to ":8081". Flag: --health-probe-bind-address.
+	// +optional
+	HealthProbeBindAddress string `json:"healthProbeBindAddress,omitempty"`
+
+	// LeaderElection configures leader election.
+	// +optional
+	LeaderElection LeaderElectionConfiguration `json:"leaderElection,omitempty"`
+
+	// EnableHTTP2 enables HTTP/2 for the metrics and webhook servers.
+	// Flag: --enable-http2.
+	// +optional
+	EnableHTTP2 bool `json:"enableHTTP2,omitempty"`
+
+	// Signer configures how certificates are signed.
+	// +optional
+	Signer SignerConfiguration `json:"signer,omitempty"`
+
+	// FeatureGates enables or disables features by name.
+	// Flag: --feature-gates.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+}
+
+// MetricsConfiguration configures the metrics server.
+type MetricsConfiguration struct {
+	// BindAddress is the address to which the metrics endpoint binds. Use
+	// ":8443" for HTTPS or ":8080" for HTTP, or "0" to disable the metrics
+	// server. Defaults to "0". Flag: --metrics-bind-address.
+	// +optional
+	BindAddress string `json:"bindAddress,omitempty"`
+
+	// Secure serves the metrics endpoint over HTTPS. Defaults to true.
+	// Flag: --metrics-secure.
+	// +optional
+	Secure bool `json:"secure,omitempty"`
+
+	// CertPath is the directory that contains the metrics server certificate.
+	// If not set, a self-signed certificate is generated.
+	// Flag: --metrics-cert-path.
+	// +optional
+	CertPath string `json:"certPath,omitempty"`
+
+	// CertName is the name of the certificate file. Defaults to "tls.crt".
+	// Flag: --metrics-cert-name.
+	// +optional
+	CertName string `json:"certName,omitempty"`
+
+	// CertKey is the name of the key file. Defaults to "tls.key".
+	// Flag: --metrics-cert-key.
+	// +optional
+	CertKey string `json:"certKey,omitempty"`
+}
+
+// WebhookConfiguration configures the webhook server. The webhook server is
+// always started, because the CRDs store v1beta1 and the API server calls its
+// conversion webhooks to serve v1alpha1.
+type WebhookConfiguration struct {
+	// CertPath is the directory that contains the webhook certificate.
+	// Flag: --webhook-cert-path.
+	// +optional
+	CertPath string `json:"certPath,omitempty"`
+
+	// CertName is the name of the certificate file. Defaults to "tls.crt".
+	// Flag: --webhook-cert-name.
+	// +optional
+	CertName string `json:"certName,omitempty"`
+
+	// CertKey is the name of the key file. Defaults to "tls.key".
+	// Flag: --webhook-cert-key.
+	// +optional
+	CertKey string `json:"certKey,omitempty"`
+}
+
+// LeaderElectionConfiguration configures leader election.
+type LeaderElectionConfiguration struct {
+	// Enabled enables leader election, so that only one controller manager
+	// is active. Flag: --leader-elect.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// SignerConfiguration configures how certificates are signed.
+type SignerConfiguration struct {
+	// Backend is the signer used to issue certificates: "ca" to sign with
+	// the CA stored in the Secret of the issuer, or "http" to send requests
+	// to the signing service at the URL of the issuer. Defaults to "ca".
+	// Flag: --signer.
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
+	// SignFailurePolicy decides which signing errors make the issuer not
+	// Ready: "Credentials", "Unavailable" or "Never". Defaults to
+	// "Credentials". Flag: --sign-failure-policy.
+	// +optional
+	SignFailurePolicy string `json:"signFailurePolicy,omitempty"`
+
+	// MaxRetryDuration is how long after its creation a request is retried
+	// before it is failed. Defaults to 1m. Flag: --max-retry-duration.
+	// +optional
+	MaxRetryDuration metav1.Duration `json:"maxRetryDuration,omitempty"`
+
+	// RequeueBaseDelay is the initial delay before a failed reconcile is
+	// retried. Defaults to 5ms. Flag: --requeue-base-delay.
+	// +optional
+	RequeueBaseDelay metav1.Duration `json:"requeueBaseDelay,omitempty"`
+
+	// RequeueMaxDelay is the maximum delay before a failed reconcile is
+	// retried. Defaults to 1000s. Flag: --requeue-max-delay.
+	// +optional
+	RequeueMaxDelay metav1.Duration `json:"requeueMaxDelay,omitempty"`
+
+	// FieldOwner is the field manager used to apply the status of issuers
+	// and requests. Defaults to "sampleissuer.cert-manager.io".
+	// Flag: --field-owner.
+	// +optional
+	FieldOwner string `json:"fieldOwner,omitempty"`
+
+	// EventSource is the source of the events recorded by the controller.
+	// Defaults to the FieldOwner. Flag: --event-source.
+	// +optional
+	EventSource string `json:"eventSource,omitempty"`
+
+	// AuthorizeRequesters checks with a SubjectAccessReview that the user
+	// which created a request may "use" the issuer. The CertificateRequests
+	// of Certificates are created by cert-manager, so cert-manager must be
+	// allowed to use the issuers of Certificates. Defaults to true.
+	// Flag: --authorize-requesters.
+	// +optional
+	AuthorizeRequesters bool `json:"authorizeRequesters,omitempty"`
+}
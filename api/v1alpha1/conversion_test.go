@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/randfill"
+
+	"github.com/cert-manager/sample-external-issuer/api/v1beta1"
+)
+
+const fuzzIterations = 1000
+
+func newConversionFuzzer(t *testing.T) *randfill.Filler {
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fuzzer.FuzzerFor(
+		metafuzzer.Funcs,
+		rand.NewSource(rand.Int63()),
+		serializer.NewCodecFactory(scheme),
+	)
+}
+
+// testRoundTrip checks that both a spoke converted to the hub and back, and
+// a hub converted to a spoke and back, are unchanged.
+func testRoundTrip(t *testing.T, newSpoke func() conversion.Convertible, newHub func() conversion.Hub) {
+	t.Helper()
+	f := newConversionFuzzer(t)
+
+	t.Run("spoke-hub-spoke", func(t *testing.T) {
+		for range fuzzIterations {
+			spoke := newSpoke()
+			f.Fill(spoke)
+			spoke.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+
+			hub := newHub()
+			if err := spoke.ConvertTo(hub); err != nil {
+				t.Fatalf("ConvertTo: %v", err)
+			}
+			got := newSpoke()
+			if err := got.ConvertFrom(hub); err != nil {
+				t.Fatalf("ConvertFrom: %v", err)
+			}
+			if !apiequality.Semantic.DeepEqual(spoke, got) {
+				t.Fatalf("round trip changed the object:\nwant: %#v\ngot:  %#v", spoke, got)
+			}
+		}
+	})
+
+	t.Run("hub-spoke-hub", func(t *testing.T) {
+		for range fuzzIterations {
+			hub := newHub()
+			f.Fill(hub)
+			hub.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+
+			spoke := newSpoke()
+			if err := spoke.ConvertFrom(hub); err != nil {
+				t.Fatalf("ConvertFrom: %v", err)
+			}
+			got := newHub()
+			if err := spoke.ConvertTo(got); err != nil {
+				t.Fatalf("ConvertTo: %v", err)
+			}
+			if !apiequality.Semantic.DeepEqual(hub, got) {
+				t.Fatalf("round trip changed the object:\nwant: %#v\ngot:  %#v", hub, got)
+			}
+		}
+	})
+}
+
+func TestSampleIssuerConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &SampleIssuer{} },
+		func() conversion.Hub { return &v1beta1.SampleIssuer{} },
+	)
+}
+
+func TestSampleClusterIssuerConversionRoundTrip(t *testing.T) {
+	testRoundTrip(t,
+		func() conversion.Convertible { return &SampleClusterIssuer{} },
+		func() conversion.Hub { return &v1beta1.SampleClusterIssuer{} },
+	)
+}
+
+// TestConversionEmptyStructs checks that empty optional structs survive a round
+// trip through the other API version. The fuzz tests rarely generate them.
+func TestConversionEmptyStructs(t *testing.T) {
+	hubTests := []struct {
+		name           string
+		hub            conversion.Hub
+		newSpoke       func() conversion.Convertible
+		wantAnnotation string
+	}{
+		{
+			name:           "empty policy",
+			hub:            &v1beta1.SampleIssuer{Spec: v1beta1.IssuerSpec{Policy: &v1beta1.PolicySpec{}}},
+			newSpoke:       func() conversion.Convertible { return &SampleIssuer{} },
+			wantAnnotation: "policy",
+		},
+		{
+			name: "empty names and duration",
+			hub: &v1beta1.SampleIssuer{Spec: v1beta1.IssuerSpec{Policy: &v1beta1.PolicySpec{
+				Names:         &v1beta1.NamesPolicySpec{},
+				Duration:      &v1beta1.DurationPolicySpec{},
+				AllowedUsages: []string{"server auth"},
+			}}},
+			newSpoke:       func() conversion.Convertible { return &SampleIssuer{} },
+			wantAnnotation: "policy.duration,policy.names",
+		},
+		{
+			name: "empty namespaces",
+			hub: &v1beta1.SampleClusterIssuer{Spec: v1beta1.ClusterIssuerSpec{
+				IssuerSpec: v1beta1.IssuerSpec{Policy: &v1beta1.PolicySpec{}},
+				Namespaces: &v1beta1.NamespacesSpec{},
+			}},
+			newSpoke:       func() conversion.Convertible { return &SampleClusterIssuer{} },
+			wantAnnotation: "namespaces,policy",
+		},
+	}
+	for _, tc := range hubTests {
+		t.Run(tc.name, func(t *testing.T) {
+			spoke := tc.newSpoke()
+			if err := spoke.ConvertFrom(tc.hub); err != nil {
+				t.Fatalf("ConvertFrom: %v", err)
+			}
+			if got := spoke.(metav1.Object).GetAnnotations()[EmptyHubFieldsAnnotation]; got != tc.wantAnnotation {
+				t.Errorf("annotation %s = %q, want %q", EmptyHubFieldsAnnotation, got, tc.wantAnnotation)
+			}
+			got := reflect.New(reflect.TypeOf(tc.hub).Elem()).Interface().(conversion.Hub)
+			if err := spoke.ConvertTo(got); err != nil {
+				t.Fatalf("ConvertTo: %v", err)
+			}
+			if !apiequality.Semantic.DeepEqual(tc.hub, got) {
+				t.Errorf("round trip changed the object:\nwant: %#v\ngot:  %#v", tc.hub, got)
+			}
+		})
+	}
+
+	t.Run("empty v1alpha1 policy", func(t *testing.T) {
+		spoke := &SampleIssuer{Spec: IssuerSpec{Policy: &PolicySpec{}, AllowedUsages: []string{"server auth"}}}
+		hub := &v1beta1.SampleIssuer{}
+		if err := spoke.ConvertTo(hub); err != nil {
+			t.Fatalf("ConvertTo: %v", err)
+		}
+		if got := hub.Annotations[EmptySpokeFieldsAnnotation]; got != "policy" {
+			t.Errorf("annotation %s = %q, want %q", EmptySpokeFieldsAnnotation, got, "policy")
+		}
+		got := &SampleIssuer{}
+		if err := got.ConvertFrom(hub); err != nil {
+			t.Fatalf("ConvertFrom: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(spoke, got) {
+			t.Errorf("round trip changed the object:\nwant: %#v\ngot:  %#v", spoke, got)
+		}
+	})
+
+	t.Run("annotation does not override set fields", func(t *testing.T) {
+		spoke := &SampleIssuer{
+			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{EmptyHubFieldsAnnotation: "policy.names"}},
+			Spec:       IssuerSpec{Policy: &PolicySpec{AllowedDNSNames: []string{".example.com"}}},
+		}
+		hub := &v1beta1.SampleIssuer{}
+		if err := spoke.ConvertTo(hub); err != nil {
+			t.Fatalf("ConvertTo: %v", err)
+		}
+		if hub.Annotations != nil {
+			t.Errorf("annotations = %v, want none", hub.Annotations)
+		}
+		if got := hub.Spec.Policy.Names.AllowedDNSNames; !reflect.DeepEqual(got, []string{".example.com"}) {
+			t.Errorf("policy.names.allowedDNSNames = %v, want [.example.com]", got)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/cert-manager/sample-external-issuer/api/v1beta1"
+)
+
+// ConvertTo converts this SampleClusterIssuer to the Hub version (v1beta1).
+func (src *SampleClusterIssuer) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.SampleClusterIssuer)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	emptyFields := popEmptyFields(&dst.ObjectMeta, EmptyHubFieldsAnnotation)
+	convertIssuerSpecToHub(&src.Spec.IssuerSpec, &dst.Spec.IssuerSpec, emptyFields)
+	setEmptyFields(&dst.ObjectMeta, EmptySpokeFieldsAnnotation, emptySpokeIssuerFields(&src.Spec.IssuerSpec))
+
+	dst.Spec.Namespaces = nil
+	if in := src.Spec.DeepCopy(); in.AllowedNamespaces != nil || in.NamespaceSelector != nil {
+		dst.Spec.Namespaces = &v1beta1.NamespacesSpec{
+			Allowed:  in.AllowedNamespaces,
+			Selector: in.NamespaceSelector,
+		}
+	} else if emptyFields.Has(emptyFieldNamespaces) {
+		dst.Spec.Namespaces = &v1beta1.NamespacesSpec{}
+	}
+
+	convertIssuerStatusToHub(&src.Status, &dst.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *SampleClusterIssuer) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.SampleClusterIssuer)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	emptyFields := popEmptyFields(&dst.ObjectMeta, EmptySpokeFieldsAnnotation)
+	convertIssuerSpecFromHub(&src.Spec.IssuerSpec, &dst.Spec.IssuerSpec, emptyFields)
+
+	hubEmptyFields := emptyHubIssuerFields(&src.Spec.IssuerSpec)
+	if ns := src.Spec.Namespaces; ns != nil && ns.Allowed == nil && ns.Selector == nil {
+		hubEmptyFields.Insert(emptyFieldNamespaces)
+	}
+	setEmptyFields(&dst.ObjectMeta, EmptyHubFieldsAnnotation, hubEmptyFields)
+
+	dst.Spec.AllowedNamespaces = nil
+	dst.Spec.NamespaceSelector = nil
+	if in := src.Spec.Namespaces.DeepCopy(); in != nil {
+		dst.Spec.AllowedNamespaces = in.Allowed
+		dst.Spec.NamespaceSelector = in.Selector
+	}
+
+	convertIssuerStatusFromHub(&src.Status, &dst.Status)
+	return nil
+}
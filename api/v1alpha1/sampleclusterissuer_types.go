@@ -30,6 +30,11 @@ import (
 // +kubebuilder:printcolumn:name="LastTransition",type="string",type="date",JSONPath=".status.conditions[?(@.type==\"Ready\")].lastTransitionTime"
 // +kubebuilder:printcolumn:name="ObservedGeneration",type="integer",JSONPath=".status.conditions[?(@.type==\"Ready\")].observedGeneration"
 // +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation"
+// +kubebuilder:printcolumn:name="CAExpiry",type="date",JSONPath=".status.ca.notAfter"
+// +kubebuilder:printcolumn:name="LastChecked",type="date",JSONPath=".status.lastSuccessfulCheckTime"
+// +kubebuilder:printcolumn:name="CA",type="string",JSONPath=".status.ca.subject",priority=1
+// +kubebuilder:printcolumn:name="Fingerprint",type="string",JSONPath=".status.ca.fingerprintSHA256",priority=1
+// +kubebuilder:printcolumn:name="BackendVersion",type="string",JSONPath=".status.backendVersion",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SampleClusterIssuer is the Schema for the sampleclusterissuers API.
@@ -37,8 +42,26 @@ type SampleClusterIssuer struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   IssuerSpec            `json:"spec,omitempty"`
-	Status v1alpha1.IssuerStatus `json:"status,omitempty"`
+	Spec   ClusterIssuerSpec `json:"spec,omitempty"`
+	Status IssuerStatus      `json:"status,omitempty"`
+}
+
+// ClusterIssuerSpec defines the desired state of SampleClusterIssuer
+type ClusterIssuerSpec struct {
+	IssuerSpec `json:",inline"`
+
+	// AllowedNamespaces are the names of the namespaces whose requests may
+	// use the issuer. A request is allowed if its namespace is listed here
+	// or matches NamespaceSelector. If neither is set, requests from any
+	// namespace are allowed. CertificateSigningRequests have no namespace,
+	// so they are denied once either field is set.
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// NamespaceSelector selects the namespaces, by their labels, whose
+	// requests may use the issuer.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 func (vi *SampleClusterIssuer) GetConditions() []metav1.Condition {
@@ -0,0 +1,295 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/cert-manager/sample-external-issuer/api/v1beta1"
+)
+
+const (
+	// EmptyHubFieldsAnnotation is set on a v1alpha1 issuer converted from a
+	// v1beta1 issuer with empty optional structs, such as `policy: {}`, which
+	// have no v1alpha1 equivalent. It lists their field paths, separated by
+	// commas, so that they are restored when the issuer is converted back.
+	EmptyHubFieldsAnnotation = "sample-issuer.example.com/v1beta1-empty-fields"
+	// EmptySpokeFieldsAnnotation is set on a v1beta1 issuer converted from a
+	// v1alpha1 issuer with an empty `policy: {}`, which has no v1beta1
+	// equivalent, so that it is restored when the issuer is converted back.
+	EmptySpokeFieldsAnnotation = "sample-issuer.example.com/v1alpha1-empty-fields"
+)
+
+// The field paths listed in EmptyHubFieldsAnnotation and
+// EmptySpokeFieldsAnnotation.
+const (
+	emptyFieldPolicy         = "policy"
+	emptyFieldPolicyNames    = "policy.names"
+	emptyFieldPolicyDuration = "policy.duration"
+	emptyFieldNamespaces     = "namespaces"
+)
+
+// ConvertTo converts this SampleIssuer to the Hub version (v1beta1).
+func (src *SampleIssuer) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.SampleIssuer)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	emptyFields := popEmptyFields(&dst.ObjectMeta, EmptyHubFieldsAnnotation)
+	convertIssuerSpecToHub(&src.Spec, &dst.Spec, emptyFields)
+	setEmptyFields(&dst.ObjectMeta, EmptySpokeFieldsAnnotation, emptySpokeIssuerFields(&src.Spec))
+	convertIssuerStatusToHub(&src.Status, &dst.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version.
+func (dst *SampleIssuer) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.SampleIssuer)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	emptyFields := popEmptyFields(&dst.ObjectMeta, EmptySpokeFieldsAnnotation)
+	convertIssuerSpecFromHub(&src.Spec, &dst.Spec, emptyFields)
+	setEmptyFields(&dst.ObjectMeta, EmptyHubFieldsAnnotation, emptyHubIssuerFields(&src.Spec))
+	convertIssuerStatusFromHub(&src.Status, &dst.Status)
+	return nil
+}
+
+// popEmptyFields removes the empty fields annotation key from meta and
+// returns the field paths it lists.
+func popEmptyFields(meta *metav1.ObjectMeta, key string) sets.Set[string] {
+	value, ok := meta.Annotations[key]
+	if !ok {
+		return nil
+	}
+	delete(meta.Annotations, key)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+	return sets.New(strings.Split(value, ",")...)
+}
+
+// setEmptyFields lists fields in the empty fields annotation key of meta,
+// unless there are none.
+func setEmptyFields(meta *metav1.ObjectMeta, key string, fields sets.Set[string]) {
+	if fields.Len() == 0 {
+		return
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[key] = strings.Join(sets.List(fields), ",")
+}
+
+// emptySpokeIssuerFields returns the empty optional structs of a v1alpha1
+// spec which are lost by the conversion to v1beta1.
+func emptySpokeIssuerFields(spec *IssuerSpec) sets.Set[string] {
+	fields := sets.New[string]()
+	if spec.Policy != nil && policyEmpty(spec.Policy) {
+		fields.Insert(emptyFieldPolicy)
+	}
+	return fields
+}
+
+// emptyHubIssuerFields returns the empty optional structs of a v1beta1 spec
+// which are lost by the conversion to v1alpha1.
+func emptyHubIssuerFields(spec *v1beta1.IssuerSpec) sets.Set[string] {
+	fields := sets.New[string]()
+	p := spec.Policy
+	if p == nil {
+		return fields
+	}
+	if p.Names == nil && p.AllowedExtensions == nil && p.CELRules == nil && p.Duration == nil &&
+		p.AllowedUsages == nil && p.CAIssuance == nil {
+		fields.Insert(emptyFieldPolicy)
+	}
+	if p.Names != nil && namesPolicyEmpty(p.Names) {
+		fields.Insert(emptyFieldPolicyNames)
+	}
+	if d := p.Duration; d != nil && d.Default == nil && d.Min == nil && d.Max == nil && d.OutOfBounds == "" {
+		fields.Insert(emptyFieldPolicyDuration)
+	}
+	return fields
+}
+
+// policyEmpty returns true if none of the fields of the v1alpha1 policy are
+// set.
+func policyEmpty(p *PolicySpec) bool {
+	return p.AllowedDNSNames == nil && p.AllowedIPRanges == nil && p.AllowedURISchemes == nil &&
+		p.AllowedURIHosts == nil && p.AllowedEmailDomains == nil && p.AllowedExtensions == nil && p.CELRules == nil
+}
+
+// namesPolicyEmpty returns true if none of the fields of the v1beta1 names
+// policy are set.
+func namesPolicyEmpty(names *v1beta1.NamesPolicySpec) bool {
+	return names.AllowedDNSNames == nil && names.AllowedIPRanges == nil && names.AllowedURISchemes == nil &&
+		names.AllowedURIHosts == nil && names.AllowedEmailDomains == nil
+}
+
+// convertIssuerSpecToHub converts the flat v1alpha1 spec to the structured
+// v1beta1 spec. The durations, usages and CA issuance of the issuer are part
+// of its v1beta1 policy. The empty optional structs listed in emptyFields are
+// restored.
+func convertIssuerSpecToHub(src *IssuerSpec, dst *v1beta1.IssuerSpec, emptyFields sets.Set[string]) {
+	in := src.DeepCopy()
+	*dst = v1beta1.IssuerSpec{
+		Backend: v1beta1.BackendSpec{URL: in.URL},
+		Auth: v1beta1.AuthSpec{
+			SecretName:                    in.AuthSecretName,
+			PrivateKeyPassphraseSecretRef: (*v1beta1.SecretKeySelector)(in.PrivateKeyPassphraseSecretRef),
+		},
+		TLS:   (*v1beta1.TLSSpec)(in.TLS),
+		Retry: (*v1beta1.RetrySpec)(in.Retry),
+	}
+
+	defer restoreEmptyHubFields(dst, emptyFields)
+
+	if (in.Policy == nil || policyEmpty(in.Policy)) && in.DefaultDuration == nil && in.MinDuration == nil &&
+		in.MaxDuration == nil && in.DurationPolicy == "" && in.AllowedUsages == nil && in.CAIssuance == nil {
+		return
+	}
+
+	policy := &v1beta1.PolicySpec{AllowedUsages: in.AllowedUsages}
+	if p := in.Policy; p != nil {
+		if p.AllowedDNSNames != nil || p.AllowedIPRanges != nil || p.AllowedURISchemes != nil ||
+			p.AllowedURIHosts != nil || p.AllowedEmailDomains != nil {
+			policy.Names = &v1beta1.NamesPolicySpec{
+				AllowedDNSNames:     p.AllowedDNSNames,
+				AllowedIPRanges:     p.AllowedIPRanges,
+				AllowedURISchemes:   p.AllowedURISchemes,
+				AllowedURIHosts:     p.AllowedURIHosts,
+				AllowedEmailDomains: p.AllowedEmailDomains,
+			}
+		}
+		policy.AllowedExtensions = p.AllowedExtensions
+		if p.CELRules != nil {
+			policy.CELRules = make([]v1beta1.CELRule, len(p.CELRules))
+			for i, rule := range p.CELRules {
+				policy.CELRules[i] = v1beta1.CELRule(rule)
+			}
+		}
+	}
+	if in.DefaultDuration != nil || in.MinDuration != nil || in.MaxDuration != nil || in.DurationPolicy != "" {
+		policy.Duration = &v1beta1.DurationPolicySpec{
+			Default:     in.DefaultDuration,
+			Min:         in.MinDuration,
+			Max:         in.MaxDuration,
+			OutOfBounds: v1beta1.DurationPolicy(in.DurationPolicy),
+		}
+	}
+	if ca := in.CAIssuance; ca != nil {
+		policy.CAIssuance = &v1beta1.CAIssuanceSpec{
+			MaxPathLen:      ca.MaxPathLen,
+			NameConstraints: (*v1beta1.NameConstraintsSpec)(ca.NameConstraints),
+		}
+	}
+	dst.Policy = policy
+}
+
+// restoreEmptyHubFields sets the empty optional structs listed in emptyFields
+// which are not set in spec.
+func restoreEmptyHubFields(spec *v1beta1.IssuerSpec, emptyFields sets.Set[string]) {
+	if emptyFields.HasAny(emptyFieldPolicy, emptyFieldPolicyNames, emptyFieldPolicyDuration) && spec.Policy == nil {
+		spec.Policy = &v1beta1.PolicySpec{}
+	}
+	if emptyFields.Has(emptyFieldPolicyNames) && spec.Policy.Names == nil {
+		spec.Policy.Names = &v1beta1.NamesPolicySpec{}
+	}
+	if emptyFields.Has(emptyFieldPolicyDuration) && spec.Policy.Duration == nil {
+		spec.Policy.Duration = &v1beta1.DurationPolicySpec{}
+	}
+}
+
+// convertIssuerSpecFromHub converts the structured v1beta1 spec to the flat
+// v1alpha1 spec. An empty policy is restored if it is listed in emptyFields.
+func convertIssuerSpecFromHub(src *v1beta1.IssuerSpec, dst *IssuerSpec, emptyFields sets.Set[string]) {
+	in := src.DeepCopy()
+	*dst = IssuerSpec{
+		URL:                           in.Backend.URL,
+		AuthSecretName:                in.Auth.SecretName,
+		PrivateKeyPassphraseSecretRef: (*SecretKeySelector)(in.Auth.PrivateKeyPassphraseSecretRef),
+		TLS:                           (*TLSSpec)(in.TLS),
+		Retry:                         (*RetrySpec)(in.Retry),
+	}
+
+	if emptyFields.Has(emptyFieldPolicy) {
+		defer func() {
+			if dst.Policy == nil {
+				dst.Policy = &PolicySpec{}
+			}
+		}()
+	}
+
+	p := in.Policy
+	if p == nil {
+		return
+	}
+
+	if (p.Names != nil && !namesPolicyEmpty(p.Names)) || p.AllowedExtensions != nil || p.CELRules != nil {
+		dst.Policy = &PolicySpec{AllowedExtensions: p.AllowedExtensions}
+		if names := p.Names; names != nil {
+			dst.Policy.AllowedDNSNames = names.AllowedDNSNames
+			dst.Policy.AllowedIPRanges = names.AllowedIPRanges
+			dst.Policy.AllowedURISchemes = names.AllowedURISchemes
+			dst.Policy.AllowedURIHosts = names.AllowedURIHosts
+			dst.Policy.AllowedEmailDomains = names.AllowedEmailDomains
+		}
+		if p.CELRules != nil {
+			dst.Policy.CELRules = make([]CELRule, len(p.CELRules))
+			for i, rule := range p.CELRules {
+				dst.Policy.CELRules[i] = CELRule(rule)
+			}
+		}
+	}
+	if d := p.Duration; d != nil {
+		dst.DefaultDuration = d.Default
+		dst.MinDuration = d.Min
+		dst.MaxDuration = d.Max
+		dst.DurationPolicy = DurationPolicy(d.OutOfBounds)
+	}
+	dst.AllowedUsages = p.AllowedUsages
+	if ca := p.CAIssuance; ca != nil {
+		dst.CAIssuance = &CAIssuanceSpec{
+			MaxPathLen:      ca.MaxPathLen,
+			NameConstraints: (*NameConstraintsSpec)(ca.NameConstraints),
+		}
+	}
+}
+
+// convertIssuerStatusToHub converts the v1alpha1 status to the v1beta1
+// status, which has the same fields.
+func convertIssuerStatusToHub(src *IssuerStatus, dst *v1beta1.IssuerStatus) {
+	in := src.DeepCopy()
+	*dst = v1beta1.IssuerStatus{
+		IssuerStatus:            in.IssuerStatus,
+		CA:                      (*v1beta1.CAStatus)(in.CA),
+		BackendVersion:          in.BackendVersion,
+		LastSuccessfulCheckTime: in.LastSuccessfulCheckTime,
+	}
+}
+
+// convertIssuerStatusFromHub converts the v1beta1 status to the v1alpha1
+// status, which has the same fields.
+func convertIssuerStatusFromHub(src *v1beta1.IssuerStatus, dst *IssuerStatus) {
+	in := src.DeepCopy()
+	*dst = IssuerStatus{
+		IssuerStatus:            in.IssuerStatus,
+		CA:                      (*CAStatus)(in.CA),
+		BackendVersion:          in.BackendVersion,
+		LastSuccessfulCheckTime: in.LastSuccessfulCheckTime,
+	}
+}
@@ -21,12 +21,140 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAIssuanceSpec) DeepCopyInto(out *CAIssuanceSpec) {
+	*out = *in
+	if in.MaxPathLen != nil {
+		in, out := &in.MaxPathLen, &out.MaxPathLen
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NameConstraints != nil {
+		in, out := &in.NameConstraints, &out.NameConstraints
+		*out = new(NameConstraintsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAIssuanceSpec.
+func (in *CAIssuanceSpec) DeepCopy() *CAIssuanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CAIssuanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAStatus) DeepCopyInto(out *CAStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAStatus.
+func (in *CAStatus) DeepCopy() *CAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELRule) DeepCopyInto(out *CELRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELRule.
+func (in *CELRule) DeepCopy() *CELRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CELRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterIssuerSpec) DeepCopyInto(out *ClusterIssuerSpec) {
+	*out = *in
+	in.IssuerSpec.DeepCopyInto(&out.IssuerSpec)
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIssuerSpec.
+func (in *ClusterIssuerSpec) DeepCopy() *ClusterIssuerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterIssuerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IssuerSpec) DeepCopyInto(out *IssuerSpec) {
 	*out = *in
+	if in.PrivateKeyPassphraseSecretRef != nil {
+		in, out := &in.PrivateKeyPassphraseSecretRef, &out.PrivateKeyPassphraseSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(PolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultDuration != nil {
+		in, out := &in.DefaultDuration, &out.DefaultDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinDuration != nil {
+		in, out := &in.MinDuration, &out.MinDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AllowedUsages != nil {
+		in, out := &in.AllowedUsages, &out.AllowedUsages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CAIssuance != nil {
+		in, out := &in.CAIssuance, &out.CAIssuance
+		*out = new(CAIssuanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerSpec.
@@ -39,12 +167,152 @@ func (in *IssuerSpec) DeepCopy() *IssuerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
+	*out = *in
+	in.IssuerStatus.DeepCopyInto(&out.IssuerStatus)
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(CAStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastSuccessfulCheckTime != nil {
+		in, out := &in.LastSuccessfulCheckTime, &out.LastSuccessfulCheckTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerStatus.
+func (in *IssuerStatus) DeepCopy() *IssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NameConstraintsSpec) DeepCopyInto(out *NameConstraintsSpec) {
+	*out = *in
+	if in.PermittedDNSDomains != nil {
+		in, out := &in.PermittedDNSDomains, &out.PermittedDNSDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedDNSDomains != nil {
+		in, out := &in.ExcludedDNSDomains, &out.ExcludedDNSDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PermittedIPRanges != nil {
+		in, out := &in.PermittedIPRanges, &out.PermittedIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedIPRanges != nil {
+		in, out := &in.ExcludedIPRanges, &out.ExcludedIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NameConstraintsSpec.
+func (in *NameConstraintsSpec) DeepCopy() *NameConstraintsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NameConstraintsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
+	*out = *in
+	if in.AllowedDNSNames != nil {
+		in, out := &in.AllowedDNSNames, &out.AllowedDNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedIPRanges != nil {
+		in, out := &in.AllowedIPRanges, &out.AllowedIPRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedURISchemes != nil {
+		in, out := &in.AllowedURISchemes, &out.AllowedURISchemes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedURIHosts != nil {
+		in, out := &in.AllowedURIHosts, &out.AllowedURIHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedEmailDomains != nil {
+		in, out := &in.AllowedEmailDomains, &out.AllowedEmailDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedExtensions != nil {
+		in, out := &in.AllowedExtensions, &out.AllowedExtensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CELRules != nil {
+		in, out := &in.CELRules, &out.CELRules
+		*out = make([]CELRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySpec.
+func (in *PolicySpec) DeepCopy() *PolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetrySpec) DeepCopyInto(out *RetrySpec) {
+	*out = *in
+	if in.MaxDuration != nil {
+		in, out := &in.MaxDuration, &out.MaxDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinBackoff != nil {
+		in, out := &in.MinBackoff, &out.MinBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetrySpec.
+func (in *RetrySpec) DeepCopy() *RetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SampleClusterIssuer) DeepCopyInto(out *SampleClusterIssuer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -103,7 +371,7 @@ func (in *SampleIssuer) DeepCopyInto(out *SampleIssuer) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -156,3 +424,38 @@ func (in *SampleIssuerList) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeySelector.
+func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSSpec) DeepCopyInto(out *TLSSpec) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSpec.
+func (in *TLSSpec) DeepCopy() *TLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
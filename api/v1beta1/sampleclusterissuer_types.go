@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/cert-manager/issuer-lib/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message"
+// +kubebuilder:printcolumn:name="LastTransition",type="string",type="date",JSONPath=".status.conditions[?(@.type==\"Ready\")].lastTransitionTime"
+// +kubebuilder:printcolumn:name="ObservedGeneration",type="integer",JSONPath=".status.conditions[?(@.type==\"Ready\")].observedGeneration"
+// +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation"
+// +kubebuilder:printcolumn:name="CAExpiry",type="date",JSONPath=".status.ca.notAfter"
+// +kubebuilder:printcolumn:name="LastChecked",type="date",JSONPath=".status.lastSuccessfulCheckTime"
+// +kubebuilder:printcolumn:name="CA",type="string",JSONPath=".status.ca.subject",priority=1
+// +kubebuilder:printcolumn:name="Fingerprint",type="string",JSONPath=".status.ca.fingerprintSHA256",priority=1
+// +kubebuilder:printcolumn:name="BackendVersion",type="string",JSONPath=".status.backendVersion",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SampleClusterIssuer is the Schema for the sampleclusterissuers API.
+type SampleClusterIssuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterIssuerSpec `json:"spec,omitempty"`
+	Status IssuerStatus      `json:"status,omitempty"`
+}
+
+// ClusterIssuerSpec defines the desired state of SampleClusterIssuer
+type ClusterIssuerSpec struct {
+	IssuerSpec `json:",inline"`
+
+	// Namespaces restricts the namespaces whose requests may use the issuer.
+	// If not set, requests from any namespace are allowed.
+	// +optional
+	Namespaces *NamespacesSpec `json:"namespaces,omitempty"`
+}
+
+// NamespacesSpec restricts the namespaces whose requests may use a
+// SampleClusterIssuer. A request is allowed if its namespace is listed in
+// Allowed or matches Selector. CertificateSigningRequests have no namespace,
+// so they are denied.
+type NamespacesSpec struct {
+	// Allowed are the names of the namespaces whose requests may use the
+	// issuer.
+	// +optional
+	Allowed []string `json:"allowed,omitempty"`
+
+	// Selector selects the namespaces, by their labels, whose requests may
+	// use the issuer.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+func (vi *SampleClusterIssuer) GetConditions() []metav1.Condition {
+	return vi.Status.Conditions
+}
+
+// GetIssuerTypeIdentifier returns a string that uniquely identifies the
+// issuer type. See the v1alpha1 SampleClusterIssuer.
+func (vi *SampleClusterIssuer) GetIssuerTypeIdentifier() string {
+	return "sampleclusterissuers.sample-issuer.example.com"
+}
+
+// issuer-lib requires that we implement the Issuer interface
+// so that it can interact with our Issuer resource.
+var _ v1alpha1.Issuer = &SampleClusterIssuer{}
+
+// +kubebuilder:object:root=true
+
+// SampleClusterIssuerList contains a list of SampleClusterIssuer.
+type SampleClusterIssuerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SampleClusterIssuer `json:"items"`
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/cert-manager/issuer-lib/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message"
+// +kubebuilder:printcolumn:name="LastTransition",type="string",type="date",JSONPath=".status.conditions[?(@.type==\"Ready\")].lastTransitionTime"
+// +kubebuilder:printcolumn:name="ObservedGeneration",type="integer",JSONPath=".status.conditions[?(@.type==\"Ready\")].observedGeneration"
+// +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation"
+// +kubebuilder:printcolumn:name="CAExpiry",type="date",JSONPath=".status.ca.notAfter"
+// +kubebuilder:printcolumn:name="LastChecked",type="date",JSONPath=".status.lastSuccessfulCheckTime"
+// +kubebuilder:printcolumn:name="CA",type="string",JSONPath=".status.ca.subject",priority=1
+// +kubebuilder:printcolumn:name="Fingerprint",type="string",JSONPath=".status.ca.fingerprintSHA256",priority=1
+// +kubebuilder:printcolumn:name="BackendVersion",type="string",JSONPath=".status.backendVersion",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SampleIssuer is the Schema for the sampleissuers API.
+type SampleIssuer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IssuerSpec   `json:"spec,omitempty"`
+	Status IssuerStatus `json:"status,omitempty"`
+}
+
+// IssuerStatus defines the observed state of SampleIssuer and
+// SampleClusterIssuer.
+type IssuerStatus struct {
+	v1alpha1.IssuerStatus `json:",inline"`
+
+	// CA is the CA certificate which signs the certificates of the issuer,
+	// as found by the last successful health check. It is not set if the
+	// signing service does not report its CA certificate.
+	// +optional
+	CA *CAStatus `json:"ca,omitempty"`
+
+	// BackendVersion is the version of the signing service, as reported by
+	// the last successful health check.
+	// +optional
+	BackendVersion string `json:"backendVersion,omitempty"`
+
+	// LastSuccessfulCheckTime is the time of the last successful health
+	// check of the issuer.
+	// +optional
+	LastSuccessfulCheckTime *metav1.Time `json:"lastSuccessfulCheckTime,omitempty"`
+}
+
+// CAStatus describes the CA certificate of an issuer.
+type CAStatus struct {
+	// Subject is the subject of the CA certificate, for example
+	// "CN=sample-issuer-ca,O=Example".
+	Subject string `json:"subject"`
+
+	// FingerprintSHA256 is the SHA-256 fingerprint of the DER encoded CA
+	// certificate, as colon separated hex bytes, which is the format printed
+	// by "openssl x509 -fingerprint -sha256".
+	FingerprintSHA256 string `json:"fingerprintSHA256"`
+
+	// NotAfter is the time when the CA certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+// IssuerSpec defines the desired state of SampleIssuer
+type IssuerSpec struct {
+	// Backend is the signing service of the issuer.
+	Backend BackendSpec `json:"backend"`
+
+	// Auth is the Secret with the credentials of the issuer.
+	Auth AuthSpec `json:"auth"`
+
+	// TLS configures the connection to the signing service. It is only used
+	// by the HTTP signer.
+	// +optional
+	TLS *TLSSpec `json:"tls,omitempty"`
+
+	// Policy restricts the certificates which are signed by the issuer.
+	// +optional
+	Policy *PolicySpec `json:"policy,omitempty"`
+
+	// Retry overrides how requests to this issuer are retried after a
+	// retryable error, for example when the signing service is unavailable.
+	// If not set, the flags of the controller are used.
+	// +optional
+	Retry *RetrySpec `json:"retry,omitempty"`
+}
+
+// BackendSpec is the signing service of an issuer.
+type BackendSpec struct {
+	// URL is the base URL for the endpoint of the signing service,
+	// for example: "https://sample-signer.example.com/api".
+	URL string `json:"url"`
+}
+
+// AuthSpec is the Secret with the credentials of an issuer.
+type AuthSpec struct {
+	// SecretName is the name of a Secret in the same namespace as the
+	// referent. If the referent is a SampleClusterIssuer, the Secret is
+	// instead looked up in the configured 'cluster resource namespace', which
+	// is set as a flag on the controller component (and defaults to the
+	// namespace that the controller runs in).
+	SecretName string `json:"secretName"`
+
+	// PrivateKeyPassphraseSecretRef is a reference to a key in a Secret
+	// which holds the passphrase of an encrypted CA private key. The Secret
+	// is looked up in the same namespace as the auth Secret. If not set, the
+	// passphrase is read from the "passphrase" key of the auth Secret.
+	// +optional
+	PrivateKeyPassphraseSecretRef *SecretKeySelector `json:"privateKeyPassphraseSecretRef,omitempty"`
+}
+
+// TLSSpec configures the connection to the signing service of an issuer.
+type TLSSpec struct {
+	// CABundle is a PEM encoded bundle of the CA certificates which are
+	// trusted to verify the certificate of the signing service. If not set,
+	// the system roots are trusted.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// PolicySpec restricts the certificates which are signed by an issuer.
+type PolicySpec struct {
+	// Names restricts the subject alternative names which may be requested.
+	// +optional
+	Names *NamesPolicySpec `json:"names,omitempty"`
+
+	// AllowedExtensions are the OIDs, for example "1.3.6.1.5.5.7.1.24" for
+	// OCSP Must-Staple, of the extensions which are copied from the CSR into
+	// the certificate. Any other extension in the CSR is dropped, and an
+	// event listing the dropped extensions is recorded on the request.
+	// Extensions which are set by the issuer, such as the subject alternative
+	// names and key usages, cannot be allowed.
+	// +optional
+	AllowedExtensions []string `json:"allowedExtensions,omitempty"`
+
+	// CELRules are CEL expressions which must all evaluate to true for a
+	// request to be signed.
+	// +optional
+	CELRules []CELRule `json:"celRules,omitempty"`
+
+	// Duration sets the default duration of certificates and bounds the
+	// duration of all requests.
+	// +optional
+	Duration *DurationPolicySpec `json:"duration,omitempty"`
+
+	// AllowedUsages are the key usages, for example "digital signature" or
+	// "client auth", which may be requested. A request for any other usage
+	// is denied. Defaults to "digital signature", "key encipherment",
+	// "server auth" and "client auth".
+	// +optional
+	AllowedUsages []string `json:"allowedUsages,omitempty"`
+
+	// CAIssuance allows the issuer to sign CA certificates, for requests with
+	// isCA set. If not set, requests for CA certificates are denied.
+	// +optional
+	CAIssuance *CAIssuanceSpec `json:"caIssuance,omitempty"`
+}
+
+// NamesPolicySpec restricts the subject alternative names which may be
+// requested. A request which contains a name that is not allowed is denied. A
+// list of allowed names which is not set allows any name of that type.
+type NamesPolicySpec struct {
+	// AllowedDNSNames are the DNS names which may be requested. A pattern is
+	// either a DNS name ("example.com"), a wildcard which matches a single
+	// label ("*.example.com") or a suffix which matches any subdomain
+	// (".example.com").
+	// +optional
+	AllowedDNSNames []string `json:"allowedDNSNames,omitempty"`
+
+	// AllowedIPRanges are the CIDR ranges, for example "10.0.0.0/8", of the
+	// IP addresses which may be requested.
+	// +optional
+	AllowedIPRanges []string `json:"allowedIPRanges,omitempty"`
+
+	// AllowedURISchemes are the schemes, for example "spiffe", of the URIs
+	// which may be requested.
+	// +optional
+	AllowedURISchemes []string `json:"allowedURISchemes,omitempty"`
+
+	// AllowedURIHosts are the hosts of the URIs which may be requested, using
+	// the same patterns as AllowedDNSNames.
+	// +optional
+	AllowedURIHosts []string `json:"allowedURIHosts,omitempty"`
+
+	// AllowedEmailDomains are the domains of the email addresses which may be
+	// requested, using the same patterns as AllowedDNSNames.
+	// +optional
+	AllowedEmailDomains []string `json:"allowedEmailDomains,omitempty"`
+}
+
+// CELRule is a CEL expression which is evaluated against the requested
+// certificate and the request metadata. The expression has access to the
+// variables:
+//
+//   - certificate: commonName, organizations, dnsNames, ipAddresses, uris,
+//     emailAddresses, duration and isCA of the requested certificate.
+//   - request: namespace, namespaceLabels, username, groups and annotations
+//     of the CertificateRequest or CertificateSigningRequest.
+type CELRule struct {
+	// Expression is a CEL expression which evaluates to a bool, for example
+	// "certificate.commonName == certificate.dnsNames[0]".
+	Expression string `json:"expression"`
+
+	// Message is the reason given when the rule denies a request. Defaults to
+	// the expression.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DurationPolicySpec sets the default duration of certificates and bounds the
+// duration of all requests.
+type DurationPolicySpec struct {
+	// Default is the duration of certificates for requests which do not
+	// specify a duration. Defaults to the default duration of cert-manager,
+	// which is 90 days.
+	// +optional
+	Default *metav1.Duration `json:"default,omitempty"`
+
+	// Min is the minimum duration of certificates.
+	// +optional
+	Min *metav1.Duration `json:"min,omitempty"`
+
+	// Max is the maximum duration of certificates. Certificates never outlive
+	// the CA certificate, regardless of this value.
+	// +optional
+	Max *metav1.Duration `json:"max,omitempty"`
+
+	// OutOfBounds decides what happens to requests for a duration outside of
+	// Min and Max. Clamp, the default, changes the duration to the nearest
+	// bound and Reject denies the request.
+	// +kubebuilder:validation:Enum=Clamp;Reject
+	// +optional
+	OutOfBounds DurationPolicy `json:"outOfBounds,omitempty"`
+}
+
+// DurationPolicy decides what happens to requests for a duration outside of
+// the bounds configured on an issuer.
+type DurationPolicy string
+
+const (
+	// DurationPolicyClamp changes the duration to the nearest bound.
+	DurationPolicyClamp DurationPolicy = "Clamp"
+	// DurationPolicyReject denies the request.
+	DurationPolicyReject DurationPolicy = "Reject"
+)
+
+// CAIssuanceSpec configures the CA certificates which are signed by an issuer.
+// CA certificates may only sign other certificates, so their key usages are
+// always cert sign and CRL sign.
+type CAIssuanceSpec struct {
+	// MaxPathLen is the maximum number of intermediate CA certificates which
+	// may follow a signed CA certificate in a chain. If not set, it is only
+	// limited by the path length of the CA certificate of the issuer.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPathLen *int32 `json:"maxPathLen,omitempty"`
+
+	// NameConstraints are added to the signed CA certificates.
+	// +optional
+	NameConstraints *NameConstraintsSpec `json:"nameConstraints,omitempty"`
+}
+
+// NameConstraintsSpec restricts the names of the certificates which may be
+// signed by a CA certificate, see RFC 5280, section 4.2.1.10.
+type NameConstraintsSpec struct {
+	// PermittedDNSDomains are the DNS domains, and their subdomains, which
+	// may be used.
+	// +optional
+	PermittedDNSDomains []string `json:"permittedDNSDomains,omitempty"`
+
+	// ExcludedDNSDomains are the DNS domains, and their subdomains, which
+	// must not be used.
+	// +optional
+	ExcludedDNSDomains []string `json:"excludedDNSDomains,omitempty"`
+
+	// PermittedIPRanges are the CIDR ranges of the IP addresses which may be
+	// used.
+	// +optional
+	PermittedIPRanges []string `json:"permittedIPRanges,omitempty"`
+
+	// ExcludedIPRanges are the CIDR ranges of the IP addresses which must not
+	// be used.
+	// +optional
+	ExcludedIPRanges []string `json:"excludedIPRanges,omitempty"`
+}
+
+// RetrySpec configures how requests to an issuer are retried.
+type RetrySpec struct {
+	// MaxDuration is how long after its creation a request is retried before
+	// it is failed. Defaults to the --max-retry-duration of the controller.
+	// +optional
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// MinBackoff is the minimum delay before a request is retried. Defaults
+	// to the --requeue-base-delay of the controller.
+	// +optional
+	MinBackoff *metav1.Duration `json:"minBackoff,omitempty"`
+
+	// MaxBackoff is the maximum delay before a request is retried. Between
+	// MinBackoff and MaxBackoff, the delay is the age of the request, so that
+	// it doubles with each retry. Defaults to the --requeue-max-delay of the
+	// controller.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// SecretKeySelector selects a key of a Secret.
+type SecretKeySelector struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// Key of the entry in the Secret's data field.
+	Key string `json:"key"`
+}
+
+func (vi *SampleIssuer) GetConditions() []metav1.Condition {
+	return vi.Status.Conditions
+}
+
+// GetIssuerTypeIdentifier returns a string that uniquely identifies the
+// issuer type. See the v1alpha1 SampleIssuer.
+func (vi *SampleIssuer) GetIssuerTypeIdentifier() string {
+	return "sampleissuers.sample-issuer.example.com"
+}
+
+// issuer-lib requires that we implement the Issuer interface
+// so that it can interact with our Issuer resource.
+var _ v1alpha1.Issuer = &SampleIssuer{}
+
+// +kubebuilder:object:root=true
+
+// SampleIssuerList contains a list of SampleIssuer.
+type SampleIssuerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SampleIssuer `json:"items"`
+}
@@ -42,11 +42,16 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/cert-manager/sample-external-issuer/internal/config"
 	"github.com/cert-manager/sample-external-issuer/internal/controllers"
+	"github.com/cert-manager/sample-external-issuer/internal/features"
 	"github.com/cert-manager/sample-external-issuer/internal/signer"
 	"github.com/cert-manager/sample-external-issuer/internal/version"
+	webhookv1alpha1 "github.com/cert-manager/sample-external-issuer/internal/webhook/v1alpha1"
+	webhookv1beta1 "github.com/cert-manager/sample-external-issuer/internal/webhook/v1beta1"
 
 	sampleissuerv1alpha1 "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	sampleissuerv1beta1 "github.com/cert-manager/sample-external-issuer/api/v1beta1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -62,42 +67,21 @@ func init() {
 	utilruntime.Must(cmapi.AddToScheme(scheme))
 
 	utilruntime.Must(sampleissuerv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(sampleissuerv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
 // nolint:gocyclo
 func main() {
-	var clusterResourceNamespace string
+	var configFile string
 	var printVersion bool
-	flag.StringVar(&clusterResourceNamespace, "cluster-resource-namespace", "",
-		"The namespace for secrets in which cluster-scoped resources are found.")
+	flag.StringVar(&configFile, "config", "",
+		"The path of a ManagerConfiguration file. Flags which are set override the values in the file.")
 	flag.BoolVar(&printVersion, "version", false, "Print version to stdout and exit")
+	cfg := config.New()
+	config.BindFlags(flag.CommandLine, cfg)
 
-	var metricsAddr string
-	var metricsCertPath, metricsCertName, metricsCertKey string
-	var webhookCertPath, webhookCertName, webhookCertKey string
-	var enableLeaderElection bool
-	var probeAddr string
-	var secureMetrics bool
-	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
-	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address to which the metrics endpoint binds. "+
-		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address to which the probe endpoint binds.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
-		"Enable leader election for controller manager. "+
-			"Enabling this will ensure there is only one active controller manager.")
-	flag.BoolVar(&secureMetrics, "metrics-secure", true,
-		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
-	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
-	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
-	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
-	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
-		"The directory that contains the metrics server certificate.")
-	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
-	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
-	flag.BoolVar(&enableHTTP2, "enable-http2", false,
-		"If set, HTTP/2 will be enabled for the metrics and webhook servers.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -114,7 +98,24 @@ func main() {
 		return
 	}
 
-	if err := getInClusterNamespace(&clusterResourceNamespace); err != nil {
+	if configFile != "" {
+		if err := config.LoadFile(flag.CommandLine, configFile, cfg); err != nil {
+			setupLog.Error(err, "invalid --config")
+			os.Exit(1)
+		}
+	}
+
+	if err := config.Validate(cfg); err != nil {
+		setupLog.Error(err, "invalid configuration")
+		os.Exit(1)
+	}
+
+	if err := features.DefaultMutableFeatureGate.SetFromMap(cfg.FeatureGates); err != nil {
+		setupLog.Error(err, "invalid feature gates")
+		os.Exit(1)
+	}
+
+	if err := getInClusterNamespace(&cfg.ClusterResourceNamespace); err != nil {
 		if errors.Is(err, errNotInCluster) {
 			setupLog.Error(err, "please supply --cluster-resource-namespace")
 		} else {
@@ -123,6 +124,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	healthCheckerBuilder, signerBuilder, err := buildersForSigner(cfg.Signer.Backend)
+	if err != nil {
+		setupLog.Error(err, "invalid --signer")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -134,7 +141,7 @@ func main() {
 		c.NextProtos = []string{"http/1.1"}
 	}
 
-	if !enableHTTP2 {
+	if !cfg.EnableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
@@ -144,14 +151,14 @@ func main() {
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 
-	if len(webhookCertPath) > 0 {
+	if len(cfg.Webhook.CertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
-			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
+			"webhook-cert-path", cfg.Webhook.CertPath, "webhook-cert-name", cfg.Webhook.CertName, "webhook-cert-key", cfg.Webhook.CertKey)
 
 		var err error
 		webhookCertWatcher, err = certwatcher.New(
-			filepath.Join(webhookCertPath, webhookCertName),
-			filepath.Join(webhookCertPath, webhookCertKey),
+			filepath.Join(cfg.Webhook.CertPath, cfg.Webhook.CertName),
+			filepath.Join(cfg.Webhook.CertPath, cfg.Webhook.CertKey),
 		)
 		if err != nil {
 			setupLog.Error(err, "Failed to initialize webhook certificate watcher")
@@ -172,12 +179,12 @@ func main() {
 	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.19.4/pkg/metrics/server
 	// - https://book.kubebuilder.io/reference/metrics.html
 	metricsServerOptions := metricsserver.Options{
-		BindAddress:   metricsAddr,
-		SecureServing: secureMetrics,
+		BindAddress:   cfg.Metrics.BindAddress,
+		SecureServing: cfg.Metrics.Secure,
 		TLSOpts:       tlsOpts,
 	}
 
-	if secureMetrics {
+	if cfg.Metrics.Secure {
 		// FilterProvider is used to protect the metrics endpoint with authn/authz.
 		// These configurations ensure that only authorized users and service accounts
 		// can access the metrics endpoint. The RBAC are configured in 'config/rbac/kustomization.yaml'. More info:
@@ -193,14 +200,14 @@ func main() {
 	// - [METRICS-WITH-CERTS] at config/default/kustomization.yaml to generate and use certificates
 	// managed by cert-manager for the metrics server.
 	// - [PROMETHEUS-WITH-CERTS] at config/prometheus/kustomization.yaml for TLS certification.
-	if len(metricsCertPath) > 0 {
+	if len(cfg.Metrics.CertPath) > 0 {
 		setupLog.Info("Initializing metrics certificate watcher using provided certificates",
-			"metrics-cert-path", metricsCertPath, "metrics-cert-name", metricsCertName, "metrics-cert-key", metricsCertKey)
+			"metrics-cert-path", cfg.Metrics.CertPath, "metrics-cert-name", cfg.Metrics.CertName, "metrics-cert-key", cfg.Metrics.CertKey)
 
 		var err error
 		metricsCertWatcher, err = certwatcher.New(
-			filepath.Join(metricsCertPath, metricsCertName),
-			filepath.Join(metricsCertPath, metricsCertKey),
+			filepath.Join(cfg.Metrics.CertPath, cfg.Metrics.CertName),
+			filepath.Join(cfg.Metrics.CertPath, cfg.Metrics.CertKey),
 		)
 		if err != nil {
 			setupLog.Error(err, "to initialize metrics certificate watcher", "error", err)
@@ -215,17 +222,18 @@ func main() {
 	setupLog.Info(
 		"starting",
 		"version", version.Version,
-		"enable-leader-election", enableLeaderElection,
-		"metrics-addr", metricsAddr,
-		"cluster-resource-namespace", clusterResourceNamespace,
+		"enable-leader-election", cfg.LeaderElection.Enabled,
+		"metrics-addr", cfg.Metrics.BindAddress,
+		"cluster-resource-namespace", cfg.ClusterResourceNamespace,
+		"signer", cfg.Signer.Backend,
 	)
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
+		HealthProbeBindAddress: cfg.HealthProbeBindAddress,
+		LeaderElection:         cfg.LeaderElection.Enabled,
 		LeaderElectionID:       "54c549fd.example.com",
 		// LeaderElectionReleaseOnCancel defines whether the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
@@ -248,14 +256,39 @@ func main() {
 	defer cancel()
 
 	if err = (&controllers.Issuer{
-		HealthCheckerBuilder:     signer.ExampleHealthCheckerFromIssuerAndSecretData,
-		SignerBuilder:            signer.ExampleSignerFromIssuerAndSecretData,
-		ClusterResourceNamespace: clusterResourceNamespace,
+		HealthCheckerBuilder:     healthCheckerBuilder,
+		SignerBuilder:            signerBuilder,
+		ClusterResourceNamespace: cfg.ClusterResourceNamespace,
+		SignFailurePolicy:        controllers.SignFailurePolicy(cfg.Signer.SignFailurePolicy),
+		MaxRetryDuration:         cfg.Signer.MaxRetryDuration.Duration,
+		RequeueBaseDelay:         cfg.Signer.RequeueBaseDelay.Duration,
+		RequeueMaxDelay:          cfg.Signer.RequeueMaxDelay.Duration,
+		FieldOwner:               cfg.Signer.FieldOwner,
+		EventSource:              cfg.Signer.EventSource,
+		AuthorizeRequesters:      cfg.Signer.AuthorizeRequesters,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create Signer controllers")
 		os.Exit(1)
 	}
 
+	if err := webhookv1alpha1.SetupSampleIssuerWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SampleIssuer")
+		os.Exit(1)
+	}
+	if err := webhookv1alpha1.SetupSampleClusterIssuerWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "SampleClusterIssuer")
+		os.Exit(1)
+	}
+	// The CRDs store v1beta1, so without the conversion webhooks the API
+	// server cannot serve the v1alpha1 objects which the controllers watch.
+	if err := webhookv1beta1.SetupSampleIssuerWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "SampleIssuer")
+		os.Exit(1)
+	}
+	if err := webhookv1beta1.SetupSampleClusterIssuerWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create conversion webhook", "webhook", "SampleClusterIssuer")
+		os.Exit(1)
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -290,6 +323,19 @@ func main() {
 	}
 }
 
+// buildersForSigner returns the HealthChecker and Signer builders for the
+// signer selected with the --signer flag.
+func buildersForSigner(name string) (controllers.HealthCheckerBuilder, controllers.SignerBuilder, error) {
+	switch name {
+	case "ca":
+		return signer.CAHealthCheckerFromIssuerAndSecretData, signer.CASignerFromIssuerAndSecretData, nil
+	case "http":
+		return signer.HTTPHealthCheckerFromIssuerAndSecretData, signer.HTTPSignerFromIssuerAndSecretData, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown signer %q, must be one of: ca, http", name)
+	}
+}
+
 var errNotInCluster = errors.New("not running in-cluster")
 
 // Copied from controller-runtime/pkg/leaderelection
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package celpolicy evaluates the CEL rules of an issuer policy against a
+// certificate template and the metadata of the request.
+package celpolicy
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/types"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+const (
+	certificateVar = "certificate"
+	requestVar     = "request"
+
+	// costLimit bounds the cost of evaluating a single rule, so that a rule
+	// cannot stall the controller.
+	costLimit = 1000000
+)
+
+// Request is the metadata of a CertificateRequest or CertificateSigningRequest
+// which is available to the rules.
+type Request struct {
+	Namespace       string
+	NamespaceLabels map[string]string
+	Username        string
+	Groups          []string
+	Annotations     map[string]string
+}
+
+// Rules are the compiled CEL rules of an issuer.
+type Rules struct {
+	rules    []sampleissuerapi.CELRule
+	programs []cel.Program
+}
+
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(certificateVar, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(requestVar, cel.MapType(cel.StringType, cel.DynType)),
+		// Optional field selection, for example request.annotations[?'team'],
+		// is also supported by Kubernetes validation rules.
+		cel.OptionalTypes(),
+	)
+}
+
+// Compile compiles the rules. It returns an error if a rule is invalid or does
+// not evaluate to a bool.
+func Compile(rules []sampleissuerapi.CELRule) (*Rules, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &Rules{rules: rules}
+	for i, rule := range rules {
+		ast, issues := env.Compile(rule.Expression)
+		if issues.Err() != nil {
+			return nil, fmt.Errorf("invalid CEL rule %d: %v", i, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("invalid CEL rule %d: must evaluate to a bool, not %v", i, ast.OutputType())
+		}
+
+		program, err := env.Program(ast, cel.CostLimit(costLimit), cel.InterruptCheckFrequency(100))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CEL rule %d: %v", i, err)
+		}
+		compiled.programs = append(compiled.programs, program)
+	}
+
+	return compiled, nil
+}
+
+// Evaluate evaluates the rules in order and returns an error for the first
+// rule which does not evaluate to true. A rule which fails to evaluate, for
+// example because it accesses an annotation which is not set, denies the
+// request.
+func (r *Rules) Evaluate(ctx context.Context, certTemplate *x509.Certificate, req Request) error {
+	if r == nil || len(r.programs) == 0 {
+		return nil
+	}
+
+	vars := map[string]any{
+		certificateVar: certificateVars(certTemplate),
+		requestVar:     requestVars(req),
+	}
+
+	for i, program := range r.programs {
+		message := r.rules[i].Message
+		if message == "" {
+			message = r.rules[i].Expression
+		}
+
+		out, _, err := program.ContextEval(ctx, vars)
+		if err != nil {
+			return fmt.Errorf("CEL rule %d failed: %s: %v", i, message, err)
+		}
+		if allowed, ok := out.Value().(bool); !ok || !allowed {
+			return fmt.Errorf("CEL rule %d denied the request: %s", i, message)
+		}
+	}
+
+	return nil
+}
+
+func certificateVars(certTemplate *x509.Certificate) map[string]any {
+	ipAddresses := make([]string, 0, len(certTemplate.IPAddresses))
+	for _, ip := range certTemplate.IPAddresses {
+		ipAddresses = append(ipAddresses, ip.String())
+	}
+	uris := make([]string, 0, len(certTemplate.URIs))
+	for _, uri := range certTemplate.URIs {
+		uris = append(uris, uri.String())
+	}
+
+	return map[string]any{
+		"commonName":     certTemplate.Subject.CommonName,
+		"organizations":  nonNil(certTemplate.Subject.Organization),
+		"dnsNames":       nonNil(certTemplate.DNSNames),
+		"ipAddresses":    ipAddresses,
+		"uris":           uris,
+		"emailAddresses": nonNil(certTemplate.EmailAddresses),
+		"duration":       certTemplate.NotAfter.Sub(certTemplate.NotBefore),
+		"isCA":           certTemplate.IsCA,
+	}
+}
+
+func requestVars(req Request) map[string]any {
+	return map[string]any{
+		"namespace":       req.Namespace,
+		"namespaceLabels": nonNilMap(req.NamespaceLabels),
+		"username":        req.Username,
+		"groups":          nonNil(req.Groups),
+		"annotations":     nonNilMap(req.Annotations),
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func nonNilMap(values map[string]string) map[string]string {
+	if values == nil {
+		return map[string]string{}
+	}
+	return values
+}
+
+// Cache caches the compiled rules of issuers by UID. The rules of an issuer
+// are compiled again when its generation changes.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[types.UID]cacheEntry
+}
+
+type cacheEntry struct {
+	generation int64
+	rules      *Rules
+	err        error
+}
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	return &Cache{entries: map[types.UID]cacheEntry{}}
+}
+
+// Get returns the compiled rules for the given generation of an issuer,
+// compiling them if they are not cached. Compile errors are cached too.
+func (c *Cache) Get(uid types.UID, generation int64, rules []sampleissuerapi.CELRule) (*Rules, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[uid]; ok && entry.generation == generation {
+		return entry.rules, entry.err
+	}
+
+	compiled, err := Compile(rules)
+	c.entries[uid] = cacheEntry{generation: generation, rules: compiled, err: err}
+	return compiled, err
+}
+
+// Delete removes the compiled rules of a deleted issuer.
+func (c *Cache) Delete(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, uid)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celpolicy
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "syntax error", expression: "certificate.commonName =="},
+		{name: "not a bool", expression: "certificate.commonName"},
+		{name: "undeclared variable", expression: "csr.commonName == 'example.com'"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Compile([]sampleissuerapi.CELRule{{Expression: tc.expression}}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Now()
+	certTemplate := func(commonName string, duration time.Duration) *x509.Certificate {
+		return &x509.Certificate{
+			Subject:   pkix.Name{CommonName: commonName},
+			DNSNames:  []string{"www.example.com", "example.com"},
+			NotBefore: now,
+			NotAfter:  now.Add(duration),
+		}
+	}
+	dev := Request{Namespace: "team-a", NamespaceLabels: map[string]string{"env": "dev"}}
+	prod := Request{Namespace: "team-b", NamespaceLabels: map[string]string{"env": "prod"}}
+
+	rules := []sampleissuerapi.CELRule{
+		{
+			Expression: "certificate.commonName == '' || certificate.commonName == certificate.dnsNames[0]",
+			Message:    "the common name must equal the first DNS name",
+		},
+		{
+			Expression: "request.namespaceLabels[?'env'].orValue('') != 'dev' || certificate.duration < duration('24h')",
+		},
+		{
+			Expression: "!('team' in request.annotations) || request.annotations['team'] == request.username",
+		},
+	}
+	compiled, err := Compile(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		certTemplate *x509.Certificate
+		req          Request
+		wantErr      bool
+	}{
+		{name: "allowed", certTemplate: certTemplate("www.example.com", time.Hour), req: dev},
+		{name: "common name mismatch", certTemplate: certTemplate("example.com", time.Hour), req: dev, wantErr: true},
+		{name: "long duration in dev", certTemplate: certTemplate("", 48*time.Hour), req: dev, wantErr: true},
+		{name: "long duration in prod", certTemplate: certTemplate("", 48*time.Hour), req: prod},
+		{
+			name:         "annotation matches username",
+			certTemplate: certTemplate("", time.Hour),
+			req:          Request{Username: "alice", Annotations: map[string]string{"team": "alice"}},
+		},
+		{
+			name:         "annotation does not match username",
+			certTemplate: certTemplate("", time.Hour),
+			req:          Request{Username: "bob", Annotations: map[string]string{"team": "alice"}},
+			wantErr:      true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := compiled.Evaluate(context.Background(), tc.certTemplate, tc.req)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("wantErr %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	cache := NewCache()
+	rules := []sampleissuerapi.CELRule{{Expression: "true"}}
+
+	first, err := cache.Get("uid", 1, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, _ := cache.Get("uid", 1, rules); cached != first {
+		t.Error("expected the compiled rules to be cached")
+	}
+	if _, err := cache.Get("uid", 2, []sampleissuerapi.CELRule{{Expression: "1"}}); err == nil {
+		t.Error("expected the rules to be compiled again for a new generation")
+	}
+
+	cache.Delete("uid")
+	if len(cache.entries) != 0 {
+		t.Errorf("expected the rules of a deleted issuer to be evicted, got %d entries", len(cache.entries))
+	}
+}
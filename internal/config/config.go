@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the configuration of the controller manager from a
+// file and from flags.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cliflag "k8s.io/component-base/cli/flag"
+	"sigs.k8s.io/yaml"
+
+	configv1alpha1 "github.com/cert-manager/sample-external-issuer/api/config/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/controllers"
+	"github.com/cert-manager/sample-external-issuer/internal/features"
+)
+
+// SignerBackends are the valid values of the signer backend.
+var SignerBackends = []string{"ca", "http"}
+
+// New returns a configuration with the default values.
+func New() *configv1alpha1.ManagerConfiguration {
+	cfg := &configv1alpha1.ManagerConfiguration{
+		Metrics: configv1alpha1.MetricsConfiguration{Secure: true},
+		Signer:  configv1alpha1.SignerConfiguration{AuthorizeRequesters: true},
+	}
+	SetDefaults(cfg)
+	return cfg
+}
+
+// SetDefaults sets the empty fields of a configuration to their defaults.
+// Booleans which default to true are set by New.
+func SetDefaults(cfg *configv1alpha1.ManagerConfiguration) {
+	cfg.APIVersion = configv1alpha1.SchemeGroupVersion.String()
+	cfg.Kind = "ManagerConfiguration"
+
+	if cfg.Metrics.BindAddress == "" {
+		cfg.Metrics.BindAddress = "0"
+	}
+	if cfg.Metrics.CertName == "" {
+		cfg.Metrics.CertName = "tls.crt"
+	}
+	if cfg.Metrics.CertKey == "" {
+		cfg.Metrics.CertKey = "tls.key"
+	}
+	if cfg.Webhook.CertName == "" {
+		cfg.Webhook.CertName = "tls.crt"
+	}
+	if cfg.Webhook.CertKey == "" {
+		cfg.Webhook.CertKey = "tls.key"
+	}
+	if cfg.HealthProbeBindAddress == "" {
+		cfg.HealthProbeBindAddress = ":8081"
+	}
+	if cfg.Signer.Backend == "" {
+		cfg.Signer.Backend = "ca"
+	}
+	if cfg.Signer.SignFailurePolicy == "" {
+		cfg.Signer.SignFailurePolicy = string(controllers.SignFailurePolicyCredentials)
+	}
+	if cfg.Signer.MaxRetryDuration.Duration == 0 {
+		cfg.Signer.MaxRetryDuration.Duration = controllers.DefaultMaxRetryDuration
+	}
+	if cfg.Signer.RequeueBaseDelay.Duration == 0 {
+		cfg.Signer.RequeueBaseDelay.Duration = controllers.DefaultRequeueBaseDelay
+	}
+	if cfg.Signer.RequeueMaxDelay.Duration == 0 {
+		cfg.Signer.RequeueMaxDelay.Duration = controllers.DefaultRequeueMaxDelay
+	}
+	if cfg.Signer.FieldOwner == "" {
+		cfg.Signer.FieldOwner = controllers.DefaultFieldOwner
+	}
+}
+
+// BindFlags adds a flag for each setting of cfg to fs. The defaults of the
+// flags are the current values of cfg.
+func BindFlags(fs *flag.FlagSet, cfg *configv1alpha1.ManagerConfiguration) {
+	fs.StringVar(&cfg.ClusterResourceNamespace, "cluster-resource-namespace", cfg.ClusterResourceNamespace,
+		"The namespace for secrets in which cluster-scoped resources are found.")
+
+	fs.StringVar(&cfg.Metrics.BindAddress, "metrics-bind-address", cfg.Metrics.BindAddress, "The address to which the metrics endpoint binds. "+
+		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
+	fs.BoolVar(&cfg.Metrics.Secure, "metrics-secure", cfg.Metrics.Secure,
+		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+	fs.StringVar(&cfg.Metrics.CertPath, "metrics-cert-path", cfg.Metrics.CertPath,
+		"The directory that contains the metrics server certificate.")
+	fs.StringVar(&cfg.Metrics.CertName, "metrics-cert-name", cfg.Metrics.CertName, "The name of the metrics server certificate file.")
+	fs.StringVar(&cfg.Metrics.CertKey, "metrics-cert-key", cfg.Metrics.CertKey, "The name of the metrics server key file.")
+
+	fs.StringVar(&cfg.Webhook.CertPath, "webhook-cert-path", cfg.Webhook.CertPath, "The directory that contains the webhook certificate.")
+	fs.StringVar(&cfg.Webhook.CertName, "webhook-cert-name", cfg.Webhook.CertName, "The name of the webhook certificate file.")
+	fs.StringVar(&cfg.Webhook.CertKey, "webhook-cert-key", cfg.Webhook.CertKey, "The name of the webhook key file.")
+
+	fs.StringVar(&cfg.HealthProbeBindAddress, "health-probe-bind-address", cfg.HealthProbeBindAddress,
+		"The address to which the probe endpoint binds.")
+	fs.BoolVar(&cfg.LeaderElection.Enabled, "leader-elect", cfg.LeaderElection.Enabled,
+		"Enable leader election for controller manager. "+
+			"Enabling this will ensure there is only one active controller manager.")
+	fs.BoolVar(&cfg.EnableHTTP2, "enable-http2", cfg.EnableHTTP2,
+		"If set, HTTP/2 will be enabled for the metrics and webhook servers.")
+
+	fs.StringVar(&cfg.Signer.Backend, "signer", cfg.Signer.Backend,
+		"The signer used to issue certificates. Use 'ca' to sign with the CA stored in the issuer's Secret, "+
+			"or 'http' to send requests to the signing service at the issuer's URL.")
+	fs.StringVar(&cfg.Signer.SignFailurePolicy, "sign-failure-policy", cfg.Signer.SignFailurePolicy,
+		"Which signing errors make the issuer not Ready until it is checked again. Use 'Credentials' when the "+
+			"signing service rejects the issuer's URL or credentials, 'Unavailable' to also include server errors "+
+			"and unreachable signing services, or 'Never'.")
+	fs.DurationVar(&cfg.Signer.MaxRetryDuration.Duration, "max-retry-duration", cfg.Signer.MaxRetryDuration.Duration,
+		"How long after its creation a request is retried before it is failed. Can be overridden by the retry settings of an issuer.")
+	fs.DurationVar(&cfg.Signer.RequeueBaseDelay.Duration, "requeue-base-delay", cfg.Signer.RequeueBaseDelay.Duration,
+		"The initial delay before a failed reconcile is retried. The delay doubles with each failure.")
+	fs.DurationVar(&cfg.Signer.RequeueMaxDelay.Duration, "requeue-max-delay", cfg.Signer.RequeueMaxDelay.Duration,
+		"The maximum delay before a failed reconcile is retried.")
+	fs.StringVar(&cfg.Signer.FieldOwner, "field-owner", cfg.Signer.FieldOwner,
+		"The field manager used to apply the status of issuers and certificate requests.")
+	fs.StringVar(&cfg.Signer.EventSource, "event-source", cfg.Signer.EventSource,
+		"The source of the events recorded by the controller. Defaults to the --field-owner.")
+	fs.BoolVar(&cfg.Signer.AuthorizeRequesters, "authorize-requesters", cfg.Signer.AuthorizeRequesters,
+		"If set, the user which created a request must be allowed to 'use' the issuer, which is checked with a SubjectAccessReview. "+
+			"The requests of Certificates are created by cert-manager, so cert-manager must be allowed to use the issuers of Certificates.")
+
+	fs.Var(cliflag.NewMapStringBool(&cfg.FeatureGates), "feature-gates",
+		"A set of key=value pairs that enable or disable features, for example 'MyFeature=true'.")
+}
+
+// LoadFile replaces cfg with the configuration in a file, and then sets the
+// flags of fs which were set on the command line again, so that they
+// override the values in the file. The flags of fs must have been bound to
+// cfg with BindFlags.
+func LoadFile(fs *flag.FlagSet, path string, cfg *configv1alpha1.ManagerConfiguration) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	loaded, err := Decode(data)
+	if err != nil {
+		return fmt.Errorf("failed to load config file %s: %v", path, err)
+	}
+
+	// The values of the flags are read before cfg is replaced, because the
+	// flags point into cfg.
+	setFlags := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = f.Value.String()
+	})
+
+	*cfg = *loaded
+	for name, value := range setFlags {
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("failed to set --%s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// Decode decodes a configuration file and converts it to the latest version.
+// Unknown fields are an error.
+func Decode(data []byte) (*configv1alpha1.ManagerConfiguration, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(data, &typeMeta); err != nil {
+		return nil, err
+	}
+
+	// A new version of the configuration is decoded in its own case, and
+	// converted to the latest version.
+	switch gvk := typeMeta.GroupVersionKind(); gvk {
+	case configv1alpha1.SchemeGroupVersion.WithKind("ManagerConfiguration"):
+		// The file is decoded over the defaults, so that omitted fields keep
+		// their default values.
+		cfg := New()
+		if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+			return nil, err
+		}
+		SetDefaults(cfg)
+		return cfg, nil
+	default:
+		return nil, fmt.Errorf("unsupported apiVersion %q and kind %q, must be %q and %q",
+			typeMeta.APIVersion, typeMeta.Kind, configv1alpha1.SchemeGroupVersion.String(), "ManagerConfiguration")
+	}
+}
+
+// Validate checks a defaulted configuration.
+func Validate(cfg *configv1alpha1.ManagerConfiguration) error {
+	var errs []error
+	if !slices.Contains(SignerBackends, cfg.Signer.Backend) {
+		errs = append(errs, fmt.Errorf("signer.backend: unknown signer %q, must be one of: %v", cfg.Signer.Backend, SignerBackends))
+	}
+	if !slices.Contains(controllers.SignFailurePolicies, controllers.SignFailurePolicy(cfg.Signer.SignFailurePolicy)) {
+		errs = append(errs, fmt.Errorf("signer.signFailurePolicy: unknown policy %q, must be one of: %v", cfg.Signer.SignFailurePolicy, controllers.SignFailurePolicies))
+	}
+	if cfg.Signer.MaxRetryDuration.Duration < 0 {
+		errs = append(errs, fmt.Errorf("signer.maxRetryDuration: must not be negative, got %s", cfg.Signer.MaxRetryDuration.Duration))
+	}
+	if cfg.Signer.RequeueBaseDelay.Duration < 0 {
+		errs = append(errs, fmt.Errorf("signer.requeueBaseDelay: must not be negative, got %s", cfg.Signer.RequeueBaseDelay.Duration))
+	}
+	if cfg.Signer.RequeueMaxDelay.Duration < cfg.Signer.RequeueBaseDelay.Duration {
+		errs = append(errs, fmt.Errorf("signer.requeueMaxDelay: must not be less than signer.requeueBaseDelay %s, got %s", cfg.Signer.RequeueBaseDelay.Duration, cfg.Signer.RequeueMaxDelay.Duration))
+	}
+	if cfg.Signer.FieldOwner == "" {
+		errs = append(errs, errors.New("signer.fieldOwner: must not be empty"))
+	}
+	if err := features.DefaultMutableFeatureGate.DeepCopy().SetFromMap(cfg.FeatureGates); err != nil {
+		errs = append(errs, fmt.Errorf("featureGates: %v", err))
+	}
+	return errors.Join(errs...)
+}
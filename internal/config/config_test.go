@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configv1alpha1 "github.com/cert-manager/sample-external-issuer/api/config/v1alpha1"
+)
+
+func writeFile(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDecode(t *testing.T) {
+	cfg, err := Decode([]byte(`
+apiVersion: config.sample-issuer.example.com/v1alpha1
+kind: ManagerConfiguration
+clusterResourceNamespace: cert-manager
+metrics:
+  bindAddress: ":8443"
+signer:
+  backend: http
+  maxRetryDuration: 1h
+  authorizeRequesters: false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ClusterResourceNamespace != "cert-manager" || cfg.Metrics.BindAddress != ":8443" || cfg.Signer.Backend != "http" {
+		t.Errorf("unexpected configuration %+v", cfg)
+	}
+	if cfg.Signer.MaxRetryDuration.Duration != time.Hour {
+		t.Errorf("unexpected max retry duration %s", cfg.Signer.MaxRetryDuration.Duration)
+	}
+	if cfg.Signer.AuthorizeRequesters {
+		t.Error("expected requesters not to be authorized")
+	}
+	if !cfg.Metrics.Secure || cfg.HealthProbeBindAddress != ":8081" || cfg.Metrics.CertName != "tls.crt" {
+		t.Errorf("expected omitted fields to be defaulted, got %+v", cfg)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "unknown field",
+			data: "apiVersion: config.sample-issuer.example.com/v1alpha1\nkind: ManagerConfiguration\nmetricz: {}\n",
+		},
+		{
+			name: "unknown version",
+			data: "apiVersion: config.sample-issuer.example.com/v1\nkind: ManagerConfiguration\n",
+		},
+		{
+			name: "missing kind",
+			data: "apiVersion: config.sample-issuer.example.com/v1alpha1\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Decode([]byte(tc.data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadFileFlagsOverride(t *testing.T) {
+	path := writeFile(t, `
+apiVersion: config.sample-issuer.example.com/v1alpha1
+kind: ManagerConfiguration
+leaderElection:
+  enabled: true
+metrics:
+  secure: false
+signer:
+  backend: http
+  fieldOwner: example.com/file
+featureGates:
+  AllAlpha: false
+  AllBeta: true
+`)
+
+	cfg := New()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	BindFlags(fs, cfg)
+	if err := fs.Parse([]string{"--signer=ca", "--metrics-secure=true", "--feature-gates=AllAlpha=true"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadFile(fs, path, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Signer.Backend != "ca" || !cfg.Metrics.Secure {
+		t.Errorf("expected the flags to override the file, got %+v", cfg)
+	}
+	if !cfg.LeaderElection.Enabled || cfg.Signer.FieldOwner != "example.com/file" {
+		t.Errorf("expected the values of the file, got %+v", cfg)
+	}
+	if !cfg.Signer.AuthorizeRequesters {
+		t.Error("expected requesters to be authorized by default")
+	}
+	if !cfg.FeatureGates["AllAlpha"] || !cfg.FeatureGates["AllBeta"] {
+		t.Errorf("expected the feature gates to be merged, got %v", cfg.FeatureGates)
+	}
+	if err := Validate(cfg); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*configv1alpha1.ManagerConfiguration)
+	}{
+		{name: "unknown signer", modify: func(cfg *configv1alpha1.ManagerConfiguration) { cfg.Signer.Backend = "vault" }},
+		{name: "unknown sign failure policy", modify: func(cfg *configv1alpha1.ManagerConfiguration) { cfg.Signer.SignFailurePolicy = "Sometimes" }},
+		{name: "negative max retry duration", modify: func(cfg *configv1alpha1.ManagerConfiguration) { cfg.Signer.MaxRetryDuration.Duration = -time.Second }},
+		{name: "requeue max delay less than base delay", modify: func(cfg *configv1alpha1.ManagerConfiguration) {
+			cfg.Signer.RequeueBaseDelay.Duration = time.Minute
+			cfg.Signer.RequeueMaxDelay.Duration = time.Second
+		}},
+		{name: "empty field owner", modify: func(cfg *configv1alpha1.ManagerConfiguration) { cfg.Signer.FieldOwner = "" }},
+		{name: "unknown feature gate", modify: func(cfg *configv1alpha1.ManagerConfiguration) { cfg.FeatureGates = map[string]bool{"Unknown": true} }},
+	}
+
+	if err := Validate(New()); err != nil {
+		t.Errorf("expected the defaults to be valid, got %v", err)
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := New()
+			tc.modify(cfg)
+			if err := Validate(cfg); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
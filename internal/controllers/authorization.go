@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+// UseVerb is the verb on sampleissuers or sampleclusterissuers which the user
+// that created a request must be granted to use an issuer. It is not a verb of
+// the Kubernetes API, it is only checked with a SubjectAccessReview.
+const UseVerb = "use"
+
+var errRequesterNotAllowed = errors.New("requester is not allowed to use the issuer")
+
+// requester is the user which created a CertificateRequest or
+// CertificateSigningRequest.
+type requester struct {
+	Username string
+	UID      string
+	Groups   []string
+	Extra    map[string][]string
+}
+
+// authorizeRequester returns an error wrapping errRequesterNotAllowed if the
+// requester is not allowed to use the issuer.
+func (o *Issuer) authorizeRequester(ctx context.Context, user requester, issuerObject client.Object) error {
+	var kind, resource string
+	switch issuerObject.(type) {
+	case *sampleissuerapi.SampleIssuer:
+		kind, resource = "SampleIssuer", "sampleissuers"
+	case *sampleissuerapi.SampleClusterIssuer:
+		kind, resource = "SampleClusterIssuer", "sampleclusterissuers"
+	default:
+		return fmt.Errorf("unexpected issuer type: %T", issuerObject)
+	}
+	issuerName := client.ObjectKeyFromObject(issuerObject).String()
+
+	if user.Username == "" && len(user.Groups) == 0 {
+		return fmt.Errorf("%w: the request has no username or groups, so it may not use %s %s", errRequesterNotAllowed, kind, issuerName)
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = v
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:     sampleissuerapi.SchemeGroupVersion.Group,
+				Resource:  resource,
+				Verb:      UseVerb,
+				Namespace: issuerObject.GetNamespace(),
+				Name:      issuerObject.GetName(),
+			},
+		},
+	}
+	if err := o.client.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to create SubjectAccessReview: %v", err)
+	}
+
+	if !review.Status.Allowed {
+		err := fmt.Errorf("%w: user %q may not %s %s %s", errRequesterNotAllowed, user.Username, UseVerb, kind, issuerName)
+		if review.Status.Reason != "" {
+			err = fmt.Errorf("%w: %s", err, review.Status.Reason)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+func TestAuthorizeRequester(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	// The authorizer allows alice to use the SampleIssuer ns1/issuer and the
+	// members of the admins group to use any issuer.
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	o := &Issuer{}
+	o.client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				reviews = append(reviews, review.Spec)
+				attrs := review.Spec.ResourceAttributes
+				switch {
+				case review.Spec.User == "error":
+					return errors.New("authorizer unavailable")
+				case review.Spec.User == "alice" && attrs.Resource == "sampleissuers" && attrs.Namespace == "ns1" && attrs.Name == "issuer":
+					review.Status.Allowed = true
+				case len(review.Spec.Groups) > 0 && review.Spec.Groups[0] == "admins":
+					review.Status.Allowed = true
+				default:
+					review.Status.Reason = "no RBAC policy matched"
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	issuer := &sampleissuerapi.SampleIssuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer"}}
+	otherIssuer := &sampleissuerapi.SampleIssuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "issuer"}}
+	clusterIssuer := &sampleissuerapi.SampleClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "issuer"}}
+
+	tests := []struct {
+		name        string
+		user        requester
+		issuer      client.Object
+		wantDenied  bool
+		wantErr     bool
+		wantReviews int
+	}{
+		{
+			name:        "user allowed to use the issuer",
+			user:        requester{Username: "alice"},
+			issuer:      issuer,
+			wantReviews: 1,
+		},
+		{
+			name:        "user not allowed to use an issuer in another namespace",
+			user:        requester{Username: "alice"},
+			issuer:      otherIssuer,
+			wantDenied:  true,
+			wantReviews: 1,
+		},
+		{
+			name:        "user not allowed to use the cluster issuer",
+			user:        requester{Username: "alice"},
+			issuer:      clusterIssuer,
+			wantDenied:  true,
+			wantReviews: 1,
+		},
+		{
+			name:        "group allowed to use the cluster issuer",
+			user:        requester{Username: "bob", Groups: []string{"admins"}},
+			issuer:      clusterIssuer,
+			wantReviews: 1,
+		},
+		{
+			name:       "request without a user",
+			issuer:     issuer,
+			wantDenied: true,
+		},
+		{
+			name:        "authorizer error",
+			user:        requester{Username: "error"},
+			issuer:      issuer,
+			wantErr:     true,
+			wantReviews: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reviews = nil
+			err := o.authorizeRequester(t.Context(), tc.user, tc.issuer)
+
+			denied := errors.Is(err, errRequesterNotAllowed)
+			if denied != tc.wantDenied {
+				t.Errorf("got denied %v, want %v: %v", denied, tc.wantDenied, err)
+			}
+			if gotErr := err != nil && !denied; gotErr != tc.wantErr {
+				t.Errorf("got error %v, want error %v", err, tc.wantErr)
+			}
+			if len(reviews) != tc.wantReviews {
+				t.Fatalf("got %d SubjectAccessReviews, want %d", len(reviews), tc.wantReviews)
+			}
+			for _, review := range reviews {
+				attrs := review.ResourceAttributes
+				if attrs.Group != "sample-issuer.example.com" || attrs.Verb != UseVerb {
+					t.Errorf("unexpected resource attributes %+v", attrs)
+				}
+			}
+		})
+	}
+}
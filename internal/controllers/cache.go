@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/resourceversion"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var signerCacheRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "sample_external_issuer_signer_cache_requests_total",
+		Help: "Number of lookups of HealthCheckers and Signers in the signer cache, by kind and result (hit or miss).",
+	},
+	[]string{"kind", "result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(signerCacheRequests)
+}
+
+// signerCacheKey identifies the inputs of the builders: the generation of an
+// issuer and the resource versions of the Secrets it references, joined by
+// "/".
+type signerCacheKey struct {
+	uid           types.UID
+	generation    int64
+	secretVersion string
+}
+
+// newerThan reports whether k was read from a newer issuer or newer Secrets
+// than other, a key of the same issuer. For the same generation, k is newer
+// if none of its Secrets is older and one of them is newer. Resource versions
+// which cannot be compared as numbers are assumed to be newer, so that the
+// cache follows the latest read.
+func (k signerCacheKey) newerThan(other signerCacheKey) bool {
+	if k.generation != other.generation {
+		return k.generation > other.generation
+	}
+
+	versions := strings.Split(k.secretVersion, "/")
+	otherVersions := strings.Split(other.secretVersion, "/")
+	if len(versions) != len(otherVersions) {
+		return true
+	}
+	newer := false
+	for i := range versions {
+		cmp, err := resourceversion.CompareResourceVersion(versions[i], otherVersions[i])
+		if err != nil {
+			return true
+		}
+		if cmp < 0 {
+			return false
+		}
+		newer = newer || cmp > 0
+	}
+	return newer
+}
+
+// signerCache caches the HealthCheckers and Signers built for issuers, so that
+// they are not built again for every request. The entry of an issuer is
+// replaced when the issuer or its Secrets change, and removed when the issuer
+// is deleted, so that its private key is not kept in memory. A reconcile which
+// read an older issuer or Secret than the entry does not replace it, so that
+// concurrent reconciles do not rebuild the entry back and forth.
+type signerCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]*signerCacheEntry
+}
+
+type signerCacheEntry struct {
+	key     signerCacheKey
+	checker HealthChecker
+	signer  Signer
+}
+
+// newSignerCache returns an empty signerCache.
+func newSignerCache() *signerCache {
+	return &signerCache{entries: map[types.UID]*signerCacheEntry{}}
+}
+
+// lookup returns the entry for key, or nil if there is none. It must be
+// called with mu held.
+func (c *signerCache) lookup(key signerCacheKey) *signerCacheEntry {
+	if e, ok := c.entries[key.uid]; ok && e.key == key {
+		return e
+	}
+	return nil
+}
+
+// store returns the entry in which to cache what was built for key,
+// replacing the entry of the issuer if it was created for an older key. It
+// returns nil if the entry of the issuer is not older than key, in which case
+// nothing is cached. It must be called with mu held.
+func (c *signerCache) store(key signerCacheKey) *signerCacheEntry {
+	e, ok := c.entries[key.uid]
+	if ok && e.key == key {
+		return e
+	}
+	if ok && !key.newerThan(e.key) {
+		return nil
+	}
+	e = &signerCacheEntry{key: key}
+	c.entries[key.uid] = e
+	return e
+}
+
+// delete removes the entry of a deleted issuer.
+func (c *signerCache) delete(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, uid)
+}
+
+// getHealthChecker returns the cached HealthChecker for key, or calls build
+// and caches its result. Builder errors are not cached.
+func (c *signerCache) getHealthChecker(key signerCacheKey, build func() (HealthChecker, error)) (HealthChecker, error) {
+	var checker HealthChecker
+	c.mu.Lock()
+	if e := c.lookup(key); e != nil {
+		checker = e.checker
+	}
+	c.mu.Unlock()
+	if checker != nil {
+		signerCacheRequests.WithLabelValues("healthchecker", "hit").Inc()
+		return checker, nil
+	}
+	signerCacheRequests.WithLabelValues("healthchecker", "miss").Inc()
+
+	// The builder is called without holding the lock, so that issuers are
+	// not blocked by each other. Concurrent misses may build more than once.
+	checker, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e := c.store(key); e != nil && e.checker == nil {
+		e.checker = checker
+	}
+	return checker, nil
+}
+
+// getSigner returns the cached Signer for key, or calls build and caches its
+// result. Builder errors are not cached.
+func (c *signerCache) getSigner(key signerCacheKey, build func() (Signer, error)) (Signer, error) {
+	var s Signer
+	c.mu.Lock()
+	if e := c.lookup(key); e != nil {
+		s = e.signer
+	}
+	c.mu.Unlock()
+	if s != nil {
+		signerCacheRequests.WithLabelValues("signer", "hit").Inc()
+		return s, nil
+	}
+	signerCacheRequests.WithLabelValues("signer", "miss").Inc()
+
+	s, err := build()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e := c.store(key); e != nil && e.signer == nil {
+		e.signer = s
+	}
+	return s, nil
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/celpolicy"
+)
+
+type fakeHealthChecker struct{}
+
+func (fakeHealthChecker) Check(context.Context) (*HealthCheckResult, error) { return nil, nil }
+
+type fakeSigner struct{ id int }
+
+func (fakeSigner) Sign(context.Context, *x509.Certificate) (*SignResult, error) {
+	return &SignResult{}, nil
+}
+
+func TestSignerCache(t *testing.T) {
+	cache := newSignerCache()
+	key := signerCacheKey{uid: "uid", generation: 1, secretVersion: "1"}
+
+	builds := 0
+	build := func() (Signer, error) {
+		builds++
+		return &fakeSigner{id: builds}, nil
+	}
+
+	hits := testutil.ToFloat64(signerCacheRequests.WithLabelValues("signer", "hit"))
+	misses := testutil.ToFloat64(signerCacheRequests.WithLabelValues("signer", "miss"))
+
+	first, err := cache.getSigner(key, build)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, _ := cache.getSigner(key, build); cached != first {
+		t.Error("expected the signer to be cached")
+	}
+	if got := testutil.ToFloat64(signerCacheRequests.WithLabelValues("signer", "hit")) - hits; got != 1 {
+		t.Errorf("expected 1 hit, got %v", got)
+	}
+	if got := testutil.ToFloat64(signerCacheRequests.WithLabelValues("signer", "miss")) - misses; got != 1 {
+		t.Errorf("expected 1 miss, got %v", got)
+	}
+
+	for _, changed := range []signerCacheKey{
+		{uid: "uid", generation: 2, secretVersion: "1"},
+		{uid: "uid", generation: 2, secretVersion: "2"},
+	} {
+		if rebuilt, _ := cache.getSigner(changed, build); rebuilt == first {
+			t.Errorf("expected the signer to be built again for %+v", changed)
+		}
+	}
+	if builds != 3 {
+		t.Errorf("expected 3 builds, got %d", builds)
+	}
+	if len(cache.entries) != 1 {
+		t.Errorf("expected the old entries to be evicted, got %d entries", len(cache.entries))
+	}
+}
+
+func TestSignerCacheKeyNewerThan(t *testing.T) {
+	key := signerCacheKey{uid: "uid", generation: 2, secretVersion: "10/20"}
+	tests := []struct {
+		name  string
+		other signerCacheKey
+		want  bool
+	}{
+		{name: "older generation", other: signerCacheKey{uid: "uid", generation: 1, secretVersion: "30/40"}, want: true},
+		{name: "newer generation", other: signerCacheKey{uid: "uid", generation: 3, secretVersion: "1/2"}},
+		{name: "same key", other: key},
+		{name: "older secret", other: signerCacheKey{uid: "uid", generation: 2, secretVersion: "9/20"}, want: true},
+		{name: "newer secret", other: signerCacheKey{uid: "uid", generation: 2, secretVersion: "10/21"}},
+		{name: "older and newer secrets", other: signerCacheKey{uid: "uid", generation: 2, secretVersion: "9/21"}},
+		{name: "invalid resource version", other: signerCacheKey{uid: "uid", generation: 2, secretVersion: "a/20"}, want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := key.newerThan(tc.other); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSignerCacheStaleKey(t *testing.T) {
+	cache := newSignerCache()
+	oldKey := signerCacheKey{uid: "uid", generation: 1, secretVersion: "1"}
+	newKey := signerCacheKey{uid: "uid", generation: 1, secretVersion: "2"}
+
+	builds := map[signerCacheKey]int{}
+	buildFor := func(key signerCacheKey) func() (Signer, error) {
+		return func() (Signer, error) {
+			builds[key]++
+			return &fakeSigner{}, nil
+		}
+	}
+
+	// A reconcile which still reads the old Secret does not evict the
+	// signer of the new one.
+	for range 3 {
+		for _, key := range []signerCacheKey{newKey, oldKey} {
+			if _, err := cache.getSigner(key, buildFor(key)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if builds[newKey] != 1 {
+		t.Errorf("expected the new signer to be built once, got %d", builds[newKey])
+	}
+	if builds[oldKey] != 3 {
+		t.Errorf("expected the old signer not to be cached, got %d builds", builds[oldKey])
+	}
+	if got := cache.entries["uid"].key; got != newKey {
+		t.Errorf("expected the entry of the new key, got %+v", got)
+	}
+}
+
+func TestSignerCacheBuildError(t *testing.T) {
+	cache := newSignerCache()
+	key := signerCacheKey{uid: "uid", generation: 1}
+
+	if _, err := cache.getHealthChecker(key, func() (HealthChecker, error) {
+		return nil, errors.New("invalid key")
+	}); err == nil {
+		t.Fatal("expected the build error to be returned")
+	}
+	checker, err := cache.getHealthChecker(key, func() (HealthChecker, error) {
+		return fakeHealthChecker{}, nil
+	})
+	if err != nil || checker == nil {
+		t.Errorf("expected the build error not to be cached, got %v", err)
+	}
+}
+
+func TestSignerCacheConcurrent(t *testing.T) {
+	cache := newSignerCache()
+	key := signerCacheKey{uid: "uid", generation: 1}
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Go(func() {
+			if _, err := cache.getSigner(key, func() (Signer, error) {
+				return &fakeSigner{}, nil
+			}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+	wg.Wait()
+
+	// Concurrent misses may build more than once, but later lookups return
+	// the signer which was cached first.
+	cached, _ := cache.getSigner(key, nil)
+	if cached == nil {
+		t.Fatal("expected a cached signer")
+	}
+}
+
+func TestSignerCacheConcurrentStaleKeys(t *testing.T) {
+	cache := newSignerCache()
+	oldKey := signerCacheKey{uid: "uid", generation: 1, secretVersion: "1"}
+	newKey := signerCacheKey{uid: "uid", generation: 2, secretVersion: "1"}
+
+	newSigner := &fakeSigner{id: 2}
+	if _, err := cache.getSigner(newKey, func() (Signer, error) { return newSigner, nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reconciles of the old and the new issuer are interleaved. The old ones
+	// build their own signer, and the new ones always hit the cache.
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Go(func() {
+			key, want := newKey, Signer(newSigner)
+			if i%2 == 0 {
+				key, want = oldKey, nil
+			}
+			got, err := cache.getSigner(key, func() (Signer, error) {
+				if key == newKey {
+					t.Error("expected the new signer to be cached")
+				}
+				return &fakeSigner{id: 1}, nil
+			})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if want != nil && got != want {
+				t.Errorf("expected the cached signer for %+v", key)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := cache.entries["uid"].key; got != newKey {
+		t.Errorf("expected the entry of the new key, got %+v", got)
+	}
+}
+
+func TestDeleteIssuer(t *testing.T) {
+	o := &Issuer{celRules: celpolicy.NewCache(), signers: newSignerCache()}
+	issuer := &sampleissuerapi.SampleIssuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer", UID: "uid"}}
+	other := &sampleissuerapi.SampleIssuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "other", UID: "other"}}
+	rules := []sampleissuerapi.CELRule{{Expression: "true"}}
+
+	compiled, err := o.celRules.Get(issuer.UID, 1, rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, uid := range []string{"uid", "other"} {
+		if _, err := o.signers.getSigner(signerCacheKey{uid: types.UID(uid)}, func() (Signer, error) {
+			return &fakeSigner{}, nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	o.deleteIssuer(issuer)
+	if _, ok := o.signers.entries[issuer.UID]; ok {
+		t.Error("expected the signer of the deleted issuer to be evicted")
+	}
+	if _, ok := o.signers.entries[other.UID]; !ok {
+		t.Error("expected the signer of the other issuer to be kept")
+	}
+	if recompiled, _ := o.celRules.Get(issuer.UID, 1, rules); recompiled == compiled {
+		t.Error("expected the CEL rules of the deleted issuer to be evicted")
+	}
+
+	// Issuers without caches, as in tests, are deleted too.
+	(&Issuer{}).deleteIssuer(other)
+}
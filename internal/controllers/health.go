@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	issuerapi "github.com/cert-manager/issuer-lib/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+const (
+	// ConditionTypeHealthy is the type of the issuer condition which records
+	// the result of the last health check.
+	ConditionTypeHealthy = "Healthy"
+
+	// HealthCheckReasonChecked is used when the health check succeeded.
+	HealthCheckReasonChecked = "Checked"
+	// HealthCheckReasonFailed is used when the health check failed without
+	// returning a HealthCheckError.
+	HealthCheckReasonFailed = "Failed"
+	// HealthCheckReasonUnreachable is used when the signing service could not
+	// be reached or responded with an unexpected error.
+	HealthCheckReasonUnreachable = "Unreachable"
+	// HealthCheckReasonUnauthorized is used when the signing service rejected
+	// the credentials in the auth Secret.
+	HealthCheckReasonUnauthorized = "Unauthorized"
+	// HealthCheckReasonInvalidResponse is used when the response of the
+	// signing service could not be decoded, for example because the CA
+	// certificate it reports does not parse.
+	HealthCheckReasonInvalidResponse = "InvalidResponse"
+	// HealthCheckReasonCAExpired is used when the CA certificate has expired.
+	HealthCheckReasonCAExpired = "CAExpired"
+	// HealthCheckReasonCAExpiringSoon is used when the CA certificate is about
+	// to expire. The issuer remains Ready.
+	HealthCheckReasonCAExpiringSoon = "CAExpiringSoon"
+)
+
+// HealthCheckError is returned by a HealthChecker to report why the issuer is
+// not healthy. Reason is used as the reason of the Healthy condition.
+type HealthCheckError struct {
+	Reason string
+	Err    error
+
+	// Warning is set if the issuer can still be used to sign certificates,
+	// in which case the issuer remains Ready.
+	Warning bool
+}
+
+func (e *HealthCheckError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+func (e *HealthCheckError) Unwrap() error {
+	return e.Err
+}
+
+// healthCheckErrorFor returns the error that should be returned to
+// issuer-lib for an error returned by a HealthChecker. Warnings are not
+// returned.
+func healthCheckErrorFor(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var healthErr *HealthCheckError
+	if !errors.As(err, &healthErr) {
+		return fmt.Errorf("%w: %v", errHealthCheckerCheck, err)
+	}
+	if healthErr.Warning {
+		return nil
+	}
+	return healthErr
+}
+
+// healthCheckFieldOwner returns the field manager used to apply the Healthy
+// condition. It must differ from the field owner used by issuer-lib, so that
+// the conditions applied by issuer-lib do not remove it.
+func (o *Issuer) healthCheckFieldOwner() string {
+	fieldOwner := o.FieldOwner
+	if fieldOwner == "" {
+		fieldOwner = DefaultFieldOwner
+	}
+	return fieldOwner + "/healthcheck"
+}
+
+// healthCheckReasonFor returns the reason of the Healthy condition for the
+// error of a health check.
+func healthCheckReasonFor(checkErr error) string {
+	if checkErr == nil {
+		return HealthCheckReasonChecked
+	}
+	var healthErr *HealthCheckError
+	if errors.As(checkErr, &healthErr) {
+		return healthErr.Reason
+	}
+	return HealthCheckReasonFailed
+}
+
+// issuerStatusOf returns the status of a SampleIssuer or SampleClusterIssuer,
+// or nil for any other issuer.
+func issuerStatusOf(issuerObject issuerapi.Issuer) *sampleissuerapi.IssuerStatus {
+	switch t := issuerObject.(type) {
+	case *sampleissuerapi.SampleIssuer:
+		return &t.Status
+	case *sampleissuerapi.SampleClusterIssuer:
+		return &t.Status
+	default:
+		return nil
+	}
+}
+
+// caStatusFor returns the status of the CA certificate of an issuer.
+func caStatusFor(cert *x509.Certificate) *sampleissuerapi.CAStatus {
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := make([]string, len(sum))
+	for i, b := range sum {
+		fingerprint[i] = fmt.Sprintf("%02X", b)
+	}
+	return &sampleissuerapi.CAStatus{
+		Subject:           cert.Subject.String(),
+		FingerprintSHA256: strings.Join(fingerprint, ":"),
+		NotAfter:          metav1.NewTime(cert.NotAfter),
+	}
+}
+
+// setHealthStatus applies the Healthy condition for the result of a health
+// check to the status of the issuer. A successful check, including one with a
+// warning, also records the CA and the backend version that it found and the
+// time of the check. A failed check keeps those of the last successful check.
+func (o *Issuer) setHealthStatus(ctx context.Context, issuerObject issuerapi.Issuer, result *HealthCheckResult, checkErr error) error {
+	condition := metav1.Condition{
+		Type:               ConditionTypeHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             healthCheckReasonFor(checkErr),
+		Message:            "Succeeded checking the issuer",
+		ObservedGeneration: issuerObject.GetGeneration(),
+	}
+	if checkErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = checkErr.Error()
+
+		var healthErr *HealthCheckError
+		if errors.As(checkErr, &healthErr) {
+			condition.Message = healthErr.Err.Error()
+		}
+	}
+
+	existing := meta.FindStatusCondition(issuerObject.GetConditions(), ConditionTypeHealthy)
+	if existing != nil && existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	} else {
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	status := sampleissuerapi.IssuerStatus{}
+	status.Conditions = []metav1.Condition{condition}
+	if healthCheckErrorFor(checkErr) == nil {
+		if result != nil {
+			if result.CA != nil {
+				status.CA = caStatusFor(result.CA)
+			}
+			status.BackendVersion = result.BackendVersion
+		}
+		now := metav1.Now()
+		status.LastSuccessfulCheckTime = &now
+	} else {
+		if existing != nil && *existing == condition {
+			return nil
+		}
+		// Fields which are left out of the apply are removed, so the fields
+		// of the last successful check are applied again.
+		if current := issuerStatusOf(issuerObject); current != nil {
+			status.CA = current.CA
+			status.BackendVersion = current.BackendVersion
+			status.LastSuccessfulCheckTime = current.LastSuccessfulCheckTime
+		}
+	}
+
+	gvk, err := apiutil.GVKForObject(issuerObject, o.client.Scheme())
+	if err != nil {
+		return err
+	}
+
+	unstructuredStatus, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return err
+	}
+
+	patch := &unstructured.Unstructured{}
+	patch.SetGroupVersionKind(gvk)
+	patch.SetName(issuerObject.GetName())
+	patch.SetNamespace(issuerObject.GetNamespace())
+	if err := unstructured.SetNestedMap(patch.Object, unstructuredStatus, "status"); err != nil {
+		return err
+	}
+
+	if err := o.client.Status().Apply(
+		ctx,
+		client.ApplyConfigurationFromUnstructured(patch),
+		client.FieldOwner(o.healthCheckFieldOwner()),
+		client.ForceOwnership,
+	); err != nil {
+		return fmt.Errorf("failed to set %s status: %v", ConditionTypeHealthy, err)
+	}
+
+	return nil
+}
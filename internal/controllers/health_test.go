@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+func TestCAStatusFor(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	cert := &x509.Certificate{
+		Raw:      []byte("certificate"),
+		Subject:  pkix.Name{CommonName: "sample-issuer-ca", Organization: []string{"Example"}},
+		NotAfter: notAfter,
+	}
+
+	status := caStatusFor(cert)
+	if status.Subject != "CN=sample-issuer-ca,O=Example" {
+		t.Errorf("unexpected subject %q", status.Subject)
+	}
+	// echo -n certificate | sha256sum
+	const wantFingerprint = "03:D6:6D:D0:88:35:C1:CA:3F:12:8C:CE:AC:D1:F3:1A:C9:41:63:09:6B:20:F4:45:AE:84:28:5B:C0:83:2D:72"
+	if status.FingerprintSHA256 != wantFingerprint {
+		t.Errorf("expected fingerprint %q, got %q", wantFingerprint, status.FingerprintSHA256)
+	}
+	if !status.NotAfter.Time.Equal(notAfter) {
+		t.Errorf("expected NotAfter %v, got %v", notAfter, status.NotAfter)
+	}
+}
+
+func TestSetHealthStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := sampleissuerapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := &sampleissuerapi.SampleIssuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer"}}
+	o := &Issuer{}
+	o.client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(issuer).
+		WithStatusSubresource(issuer).
+		Build()
+
+	ctx := context.Background()
+	get := func() *sampleissuerapi.SampleIssuer {
+		t.Helper()
+		got := &sampleissuerapi.SampleIssuer{}
+		if err := o.client.Get(ctx, client.ObjectKeyFromObject(issuer), got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	ca := &x509.Certificate{
+		Raw:      []byte("certificate"),
+		Subject:  pkix.Name{CommonName: "sample-issuer-ca"},
+		NotAfter: time.Now().Add(time.Hour),
+	}
+	if err := o.setHealthStatus(ctx, get(), &HealthCheckResult{CA: ca, BackendVersion: "v1.2.3"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	checked := get()
+	if condition := meta.FindStatusCondition(checked.Status.Conditions, ConditionTypeHealthy); condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected a true %s condition, got %v", ConditionTypeHealthy, condition)
+	}
+	if checked.Status.CA == nil || checked.Status.CA.Subject != "CN=sample-issuer-ca" {
+		t.Errorf("expected the CA in the status, got %v", checked.Status.CA)
+	}
+	if checked.Status.BackendVersion != "v1.2.3" {
+		t.Errorf("expected the backend version in the status, got %q", checked.Status.BackendVersion)
+	}
+	if checked.Status.LastSuccessfulCheckTime == nil {
+		t.Error("expected the time of the check in the status")
+	}
+
+	// A failed check keeps the CA and the time of the last successful check.
+	if err := o.setHealthStatus(ctx, checked, nil, errors.New("unreachable")); err != nil {
+		t.Fatal(err)
+	}
+	failed := get()
+	if condition := meta.FindStatusCondition(failed.Status.Conditions, ConditionTypeHealthy); condition == nil || condition.Status != metav1.ConditionFalse {
+		t.Errorf("expected a false %s condition, got %v", ConditionTypeHealthy, condition)
+	}
+	if *failed.Status.CA != *checked.Status.CA || failed.Status.BackendVersion != checked.Status.BackendVersion ||
+		!failed.Status.LastSuccessfulCheckTime.Equal(checked.Status.LastSuccessfulCheckTime) {
+		t.Errorf("expected the status of the last successful check, got %+v", failed.Status)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	issuerapi "github.com/cert-manager/issuer-lib/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+// The results of Sign, which are the values of the result label of
+// signRequests.
+const (
+	signResultSuccess = "success"
+	// signResultPermanent is used for PermanentErrors, which fail the
+	// request.
+	signResultPermanent = "permanent"
+	// signResultIssuer is used for IssuerErrors, which make the issuer not
+	// Ready.
+	signResultIssuer = "issuer"
+	// signResultRetriable is used for any other error, after which the
+	// request is retried.
+	signResultRetriable = "retriable"
+)
+
+// issuerLabels are the labels which identify an issuer in the metrics.
+var issuerLabels = []string{"kind", "namespace", "name"}
+
+var (
+	signDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "sample_external_issuer_sign_duration_seconds",
+			Help:    "Duration of the signing of certificate requests, by issuer.",
+			Buckets: prometheus.DefBuckets,
+		},
+		issuerLabels,
+	)
+	signRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sample_external_issuer_sign_requests_total",
+			Help: "Number of signings of certificate requests, by issuer and result (success, or the class of the error: permanent, issuer or retriable).",
+		},
+		append(issuerLabels, "result"),
+	)
+	healthChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sample_external_issuer_health_checks_total",
+			Help: "Number of health checks of issuers, by issuer and the reason of the resulting Healthy condition.",
+		},
+		append(issuerLabels, "reason"),
+	)
+	caNotAfter = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "sample_external_issuer_ca_not_after_timestamp_seconds",
+			Help: "Time when the CA certificate of an issuer expires, in seconds since the epoch, as found by the last successful health check.",
+		},
+		issuerLabels,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(signDuration, signRequests, healthChecks, caNotAfter)
+}
+
+// issuerLabelValues returns the values of issuerLabels for an issuer.
+func issuerLabelValues(issuerObject client.Object) []string {
+	kind := issuerObject.GetObjectKind().GroupVersionKind().Kind
+	switch issuerObject.(type) {
+	case *sampleissuerapi.SampleIssuer:
+		kind = "SampleIssuer"
+	case *sampleissuerapi.SampleClusterIssuer:
+		kind = "SampleClusterIssuer"
+	}
+	return []string{kind, issuerObject.GetNamespace(), issuerObject.GetName()}
+}
+
+// recordSign records the result and the duration of a call to Sign.
+func recordSign(issuerObject issuerapi.Issuer, result string, duration time.Duration) {
+	labels := issuerLabelValues(issuerObject)
+	signDuration.WithLabelValues(labels...).Observe(duration.Seconds())
+	signRequests.WithLabelValues(append(labels, result)...).Inc()
+}
+
+// recordHealthCheck records the result of a health check. The expiry of the
+// CA certificate is only updated by a successful check.
+func recordHealthCheck(issuerObject issuerapi.Issuer, result *HealthCheckResult, checkErr error) {
+	labels := issuerLabelValues(issuerObject)
+	healthChecks.WithLabelValues(append(labels, healthCheckReasonFor(checkErr))...).Inc()
+
+	if healthCheckErrorFor(checkErr) != nil {
+		return
+	}
+	if result == nil || result.CA == nil {
+		caNotAfter.DeleteLabelValues(labels...)
+		return
+	}
+	caNotAfter.WithLabelValues(labels...).Set(float64(result.CA.NotAfter.Unix()))
+}
+
+// deleteIssuerMetrics deletes the series of a deleted issuer.
+func deleteIssuerMetrics(issuerObject client.Object) {
+	labels := issuerLabelValues(issuerObject)
+	partial := prometheus.Labels{}
+	for i, name := range issuerLabels {
+		partial[name] = labels[i]
+	}
+	signDuration.DeletePartialMatch(partial)
+	signRequests.DeletePartialMatch(partial)
+	healthChecks.DeletePartialMatch(partial)
+	caNotAfter.DeletePartialMatch(partial)
+}
+
+// deleteIssuer deletes the series and the cached state of a deleted issuer.
+func (o *Issuer) deleteIssuer(issuerObject client.Object) {
+	deleteIssuerMetrics(issuerObject)
+	if o.celRules != nil {
+		o.celRules.Delete(issuerObject.GetUID())
+	}
+	if o.signers != nil {
+		o.signers.delete(issuerObject.GetUID())
+	}
+}
+
+// setupIssuerCleanup deletes the series and the cached CEL rules and signers
+// of issuers when they are deleted, so that, for example, the expiry of their
+// CA certificates is not reported any more and their private keys are not
+// kept in memory.
+func (o *Issuer) setupIssuerCleanup(gvk schema.GroupVersionKind, b *builder.Builder) {
+	var issuer client.Object
+	switch gvk {
+	case sampleissuerapi.SchemeGroupVersion.WithKind("SampleIssuer"):
+		issuer = &sampleissuerapi.SampleIssuer{}
+	case sampleissuerapi.SchemeGroupVersion.WithKind("SampleClusterIssuer"):
+		issuer = &sampleissuerapi.SampleClusterIssuer{}
+	default:
+		return
+	}
+
+	b.Watches(issuer, handler.Funcs{
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			o.deleteIssuer(e.Object)
+		},
+	})
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/x509"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+func resetIssuerMetrics() {
+	signDuration.Reset()
+	signRequests.Reset()
+	healthChecks.Reset()
+	caNotAfter.Reset()
+}
+
+func TestSignMetrics(t *testing.T) {
+	resetIssuerMetrics()
+	t.Cleanup(resetIssuerMetrics)
+
+	issuer := &sampleissuerapi.SampleIssuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer"}}
+	clusterIssuer := &sampleissuerapi.SampleClusterIssuer{ObjectMeta: metav1.ObjectMeta{Name: "issuer"}}
+
+	recordSign(issuer, signResultSuccess, 300*time.Millisecond)
+	recordSign(issuer, signResultPermanent, 300*time.Millisecond)
+	recordSign(clusterIssuer, signResultRetriable, 3*time.Second)
+
+	expected := `
+# HELP sample_external_issuer_sign_duration_seconds Duration of the signing of certificate requests, by issuer.
+# TYPE sample_external_issuer_sign_duration_seconds histogram
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="0.005"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="0.01"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="0.025"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="0.05"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="0.1"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="0.25"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="0.5"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="1"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="2.5"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="5"} 1
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="10"} 1
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleClusterIssuer",name="issuer",namespace="",le="+Inf"} 1
+sample_external_issuer_sign_duration_seconds_sum{kind="SampleClusterIssuer",name="issuer",namespace=""} 3
+sample_external_issuer_sign_duration_seconds_count{kind="SampleClusterIssuer",name="issuer",namespace=""} 1
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="0.005"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="0.01"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="0.025"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="0.05"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="0.1"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="0.25"} 0
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="0.5"} 2
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="1"} 2
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="2.5"} 2
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="5"} 2
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="10"} 2
+sample_external_issuer_sign_duration_seconds_bucket{kind="SampleIssuer",name="issuer",namespace="ns1",le="+Inf"} 2
+sample_external_issuer_sign_duration_seconds_sum{kind="SampleIssuer",name="issuer",namespace="ns1"} 0.6
+sample_external_issuer_sign_duration_seconds_count{kind="SampleIssuer",name="issuer",namespace="ns1"} 2
+# HELP sample_external_issuer_sign_requests_total Number of signings of certificate requests, by issuer and result (success, or the class of the error: permanent, issuer or retriable).
+# TYPE sample_external_issuer_sign_requests_total counter
+sample_external_issuer_sign_requests_total{kind="SampleClusterIssuer",name="issuer",namespace="",result="retriable"} 1
+sample_external_issuer_sign_requests_total{kind="SampleIssuer",name="issuer",namespace="ns1",result="permanent"} 1
+sample_external_issuer_sign_requests_total{kind="SampleIssuer",name="issuer",namespace="ns1",result="success"} 1
+`
+	if err := testutil.GatherAndCompare(metrics.Registry, strings.NewReader(expected),
+		"sample_external_issuer_sign_duration_seconds",
+		"sample_external_issuer_sign_requests_total",
+	); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHealthCheckMetrics(t *testing.T) {
+	resetIssuerMetrics()
+	t.Cleanup(resetIssuerMetrics)
+
+	issuer := &sampleissuerapi.SampleIssuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer"}}
+	otherIssuer := &sampleissuerapi.SampleIssuer{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "other"}}
+	ca := &x509.Certificate{NotAfter: time.Unix(1893456000, 0)}
+
+	recordHealthCheck(issuer, &HealthCheckResult{CA: ca}, nil)
+	// A failed check keeps the expiry of the CA certificate.
+	recordHealthCheck(issuer, nil, &HealthCheckError{Reason: HealthCheckReasonUnreachable, Err: errors.New("connection refused")})
+	// A warning is a successful check.
+	recordHealthCheck(otherIssuer, &HealthCheckResult{CA: ca}, &HealthCheckError{
+		Reason:  HealthCheckReasonCAExpiringSoon,
+		Err:     errors.New("the CA certificate expires soon"),
+		Warning: true,
+	})
+
+	expected := `
+# HELP sample_external_issuer_ca_not_after_timestamp_seconds Time when the CA certificate of an issuer expires, in seconds since the epoch, as found by the last successful health check.
+# TYPE sample_external_issuer_ca_not_after_timestamp_seconds gauge
+sample_external_issuer_ca_not_after_timestamp_seconds{kind="SampleIssuer",name="issuer",namespace="ns1"} 1.893456e+09
+sample_external_issuer_ca_not_after_timestamp_seconds{kind="SampleIssuer",name="other",namespace="ns1"} 1.893456e+09
+# HELP sample_external_issuer_health_checks_total Number of health checks of issuers, by issuer and the reason of the resulting Healthy condition.
+# TYPE sample_external_issuer_health_checks_total counter
+sample_external_issuer_health_checks_total{kind="SampleIssuer",name="issuer",namespace="ns1",reason="Checked"} 1
+sample_external_issuer_health_checks_total{kind="SampleIssuer",name="issuer",namespace="ns1",reason="Unreachable"} 1
+sample_external_issuer_health_checks_total{kind="SampleIssuer",name="other",namespace="ns1",reason="CAExpiringSoon"} 1
+`
+	if err := testutil.GatherAndCompare(metrics.Registry, strings.NewReader(expected),
+		"sample_external_issuer_ca_not_after_timestamp_seconds",
+		"sample_external_issuer_health_checks_total",
+	); err != nil {
+		t.Error(err)
+	}
+
+	// A successful check of a signing service which does not report its CA
+	// certificate removes the expiry, and so does deleting the issuer.
+	recordHealthCheck(issuer, &HealthCheckResult{}, nil)
+	deleteIssuerMetrics(otherIssuer)
+	if count, err := testutil.GatherAndCount(metrics.Registry, "sample_external_issuer_ca_not_after_timestamp_seconds"); err != nil || count != 0 {
+		t.Errorf("expected no CA expiry series, got %d (%v)", count, err)
+	}
+	if count, err := testutil.GatherAndCount(metrics.Registry, "sample_external_issuer_health_checks_total"); err != nil || count != 2 {
+		t.Errorf("expected the health check series of the deleted issuer to be removed, got %d series (%v)", count, err)
+	}
+}
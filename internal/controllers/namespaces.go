@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+// restrictsNamespaces returns true if only requests from some namespaces may
+// use a SampleClusterIssuer.
+func restrictsNamespaces(spec *sampleissuerapi.ClusterIssuerSpec) bool {
+	return len(spec.AllowedNamespaces) > 0 || spec.NamespaceSelector != nil
+}
+
+// namespaceSelectorFor returns the namespace selector of a SampleClusterIssuer,
+// or nil if it has none.
+func namespaceSelectorFor(spec *sampleissuerapi.ClusterIssuerSpec) (labels.Selector, error) {
+	if spec.NamespaceSelector == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespaceSelector: %v", err)
+	}
+	return selector, nil
+}
+
+// checkNamespace returns a PolicyError if requests from the namespace may not
+// use the SampleClusterIssuer. The namespace of a CertificateSigningRequest is
+// empty.
+func (o *Issuer) checkNamespace(ctx context.Context, issuer *sampleissuerapi.SampleClusterIssuer, namespace string) error {
+	if !restrictsNamespaces(&issuer.Spec) {
+		return nil
+	}
+
+	if namespace == "" {
+		return &PolicyError{
+			Err: fmt.Errorf("SampleClusterIssuer %s only signs requests from allowed namespaces, and the request has no namespace", issuer.Name),
+		}
+	}
+
+	if slices.Contains(issuer.Spec.AllowedNamespaces, namespace) {
+		return nil
+	}
+
+	selector, err := namespaceSelectorFor(&issuer.Spec)
+	if err != nil {
+		return err
+	}
+	if selector != nil {
+		var ns corev1.Namespace
+		if err := o.client.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+			return fmt.Errorf("failed to get namespace %s: %v", namespace, err)
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			return nil
+		}
+	}
+
+	return &PolicyError{
+		Err: fmt.Errorf("namespace %s is not allowed to use SampleClusterIssuer %s", namespace, issuer.Name),
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+func TestCheckNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	o := &Issuer{}
+	o.client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b"}}},
+		).
+		Build()
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+
+	tests := []struct {
+		name       string
+		spec       sampleissuerapi.ClusterIssuerSpec
+		namespace  string
+		wantDenied bool
+		wantErr    bool
+	}{
+		{
+			name:      "no restriction",
+			namespace: "team-b",
+		},
+		{
+			name:      "no restriction allows requests without a namespace",
+			namespace: "",
+		},
+		{
+			name:      "allowed namespace",
+			spec:      sampleissuerapi.ClusterIssuerSpec{AllowedNamespaces: []string{"team-b"}},
+			namespace: "team-b",
+		},
+		{
+			name:       "namespace not in the list",
+			spec:       sampleissuerapi.ClusterIssuerSpec{AllowedNamespaces: []string{"team-a"}},
+			namespace:  "team-b",
+			wantDenied: true,
+		},
+		{
+			name:      "namespace matches the selector",
+			spec:      sampleissuerapi.ClusterIssuerSpec{NamespaceSelector: selector},
+			namespace: "team-a",
+		},
+		{
+			name:       "namespace does not match the selector",
+			spec:       sampleissuerapi.ClusterIssuerSpec{NamespaceSelector: selector},
+			namespace:  "team-b",
+			wantDenied: true,
+		},
+		{
+			name: "namespace in the list but not matching the selector",
+			spec: sampleissuerapi.ClusterIssuerSpec{
+				AllowedNamespaces: []string{"team-b"},
+				NamespaceSelector: selector,
+			},
+			namespace: "team-b",
+		},
+		{
+			name:       "request without a namespace",
+			spec:       sampleissuerapi.ClusterIssuerSpec{NamespaceSelector: selector},
+			namespace:  "",
+			wantDenied: true,
+		},
+		{
+			name:      "missing namespace",
+			spec:      sampleissuerapi.ClusterIssuerSpec{NamespaceSelector: selector},
+			namespace: "team-c",
+			wantErr:   true,
+		},
+		{
+			name: "invalid selector",
+			spec: sampleissuerapi.ClusterIssuerSpec{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Equals"}},
+				},
+			},
+			namespace: "team-a",
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			issuer := &sampleissuerapi.SampleClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       tc.spec,
+			}
+			err := o.checkNamespace(t.Context(), issuer, tc.namespace)
+
+			var policyErr *PolicyError
+			denied := errors.As(err, &policyErr)
+			if denied != tc.wantDenied {
+				t.Errorf("got denied %v, want %v: %v", denied, tc.wantDenied, err)
+			}
+			if gotErr := err != nil && !denied; gotErr != tc.wantErr {
+				t.Errorf("got error %v, want error %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cert-manager/issuer-lib/controllers/signer"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+const (
+	// DefaultMaxRetryDuration is how long after its creation a request is
+	// retried, if Issuer.MaxRetryDuration is not set.
+	DefaultMaxRetryDuration = 1 * time.Minute
+	// DefaultFieldOwner is the field manager used to apply the status of
+	// issuers and requests, and the default source of events.
+	DefaultFieldOwner = "sampleissuer.cert-manager.io"
+	// DefaultRequeueBaseDelay and DefaultRequeueMaxDelay bound the backoff of
+	// failed reconciles. They are the defaults of controller-runtime.
+	DefaultRequeueBaseDelay = 5 * time.Millisecond
+	DefaultRequeueMaxDelay  = 1000 * time.Second
+)
+
+// setDefaults sets the unset retry, field owner and event source settings of
+// the issuer controllers to their defaults.
+func (o *Issuer) setDefaults() {
+	if o.MaxRetryDuration == 0 {
+		o.MaxRetryDuration = DefaultMaxRetryDuration
+	}
+	if o.FieldOwner == "" {
+		o.FieldOwner = DefaultFieldOwner
+	}
+	if o.EventSource == "" {
+		o.EventSource = o.FieldOwner
+	}
+	if o.RequeueBaseDelay == 0 {
+		o.RequeueBaseDelay = DefaultRequeueBaseDelay
+	}
+	if o.RequeueMaxDelay == 0 {
+		o.RequeueMaxDelay = DefaultRequeueMaxDelay
+	}
+}
+
+// validate checks the settings of the issuer controllers, after setDefaults.
+func (o *Issuer) validate() error {
+	switch {
+	case o.MaxRetryDuration < 0:
+		return fmt.Errorf("max retry duration must not be negative, got %s", o.MaxRetryDuration)
+	case o.RequeueBaseDelay < 0:
+		return fmt.Errorf("requeue base delay must not be negative, got %s", o.RequeueBaseDelay)
+	case o.RequeueMaxDelay < o.RequeueBaseDelay:
+		return fmt.Errorf("requeue max delay %s must not be less than the requeue base delay %s", o.RequeueMaxDelay, o.RequeueBaseDelay)
+	}
+	return nil
+}
+
+// controllerOptions returns the options of a controller. Each call returns a
+// new rate limiter, so that controllers do not share the backoff of their
+// items.
+func (o *Issuer) controllerOptions() controller.Options {
+	return controller.Options{RateLimiter: o.newRateLimiter()}
+}
+
+// newRateLimiter returns the rate limiter of a controller, which backs off
+// exponentially between RequeueBaseDelay and RequeueMaxDelay.
+func (o *Issuer) newRateLimiter() workqueue.TypedRateLimiter[reconcile.Request] {
+	return workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](o.RequeueBaseDelay, o.RequeueMaxDelay)
+}
+
+// retryPolicy decides for how long and how often a request is retried.
+type retryPolicy struct {
+	maxDuration time.Duration
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+// retryPolicyFor returns the retry policy of an issuer, or nil if the issuer
+// does not override the settings of the controller, in which case issuer-lib
+// retries the requests.
+func (o *Issuer) retryPolicyFor(issuerSpec *sampleissuerapi.IssuerSpec) (*retryPolicy, error) {
+	spec := issuerSpec.Retry
+	if spec == nil {
+		return nil, nil
+	}
+
+	p := &retryPolicy{
+		maxDuration: o.MaxRetryDuration,
+		minBackoff:  o.RequeueBaseDelay,
+		maxBackoff:  o.RequeueMaxDelay,
+	}
+	if spec.MaxDuration != nil {
+		p.maxDuration = spec.MaxDuration.Duration
+	}
+	if spec.MinBackoff != nil {
+		p.minBackoff = spec.MinBackoff.Duration
+	}
+	if spec.MaxBackoff != nil {
+		p.maxBackoff = spec.MaxBackoff.Duration
+	}
+
+	switch {
+	case p.maxDuration < 0:
+		return nil, fmt.Errorf("retry.maxDuration must not be negative, got %s", p.maxDuration)
+	case p.minBackoff < 0:
+		return nil, fmt.Errorf("retry.minBackoff must not be negative, got %s", p.minBackoff)
+	case p.maxBackoff < p.minBackoff:
+		return nil, fmt.Errorf("retry.maxBackoff %s must not be less than retry.minBackoff %s", p.maxBackoff, p.minBackoff)
+	}
+	return p, nil
+}
+
+// errorFor returns the error for a request of the given age which failed with
+// err. Retryable errors are returned as PendingErrors, which issuer-lib
+// retries after RequeueAfter regardless of its MaxRetryDuration, until the
+// request is older than maxDuration. Errors which are not retried are
+// returned as they are.
+func (p *retryPolicy) errorFor(err error, age time.Duration) error {
+	if errors.As(err, &signer.PermanentError{}) ||
+		errors.As(err, &signer.IssuerError{}) ||
+		errors.As(err, &signer.PendingError{}) {
+		return err
+	}
+
+	if age >= p.maxDuration {
+		return signer.PermanentError{Err: fmt.Errorf("failed for longer than the maximum retry duration of %s: %w", p.maxDuration, err)}
+	}
+
+	return signer.PendingError{
+		Err:          err,
+		RequeueAfter: min(max(age, p.minBackoff), p.maxBackoff),
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cert-manager/issuer-lib/controllers/signer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+func TestIssuerValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		issuer  Issuer
+		wantErr bool
+	}{
+		{name: "defaults"},
+		{name: "max retry duration", issuer: Issuer{MaxRetryDuration: time.Hour}},
+		{name: "negative max retry duration", issuer: Issuer{MaxRetryDuration: -time.Second}, wantErr: true},
+		{name: "negative requeue base delay", issuer: Issuer{RequeueBaseDelay: -time.Second}, wantErr: true},
+		{name: "requeue max delay less than base delay", issuer: Issuer{RequeueBaseDelay: time.Minute, RequeueMaxDelay: time.Second}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.issuer.setDefaults()
+			if err := tc.issuer.validate(); (err != nil) != tc.wantErr {
+				t.Errorf("wantErr %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIssuerSetDefaults(t *testing.T) {
+	o := Issuer{FieldOwner: "example.com/issuer"}
+	o.setDefaults()
+	if o.EventSource != "example.com/issuer" {
+		t.Errorf("expected the event source to default to the field owner, got %q", o.EventSource)
+	}
+	if o.healthCheckFieldOwner() != "example.com/issuer/healthcheck" {
+		t.Errorf("unexpected health check field owner %q", o.healthCheckFieldOwner())
+	}
+	if o.MaxRetryDuration != DefaultMaxRetryDuration {
+		t.Errorf("unexpected max retry duration %s", o.MaxRetryDuration)
+	}
+}
+
+func TestControllerOptions(t *testing.T) {
+	o := Issuer{RequeueBaseDelay: time.Second, RequeueMaxDelay: time.Minute}
+	first, second := o.controllerOptions(), o.controllerOptions()
+
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "issuer"}}
+	for _, want := range []time.Duration{time.Second, 2 * time.Second} {
+		if got := first.RateLimiter.When(item); got != want {
+			t.Errorf("expected a backoff of %s, got %s", want, got)
+		}
+	}
+	if got := second.RateLimiter.When(item); got != time.Second {
+		t.Errorf("expected the controllers not to share the backoff of an item, got %s", got)
+	}
+}
+
+func TestRetryPolicyFor(t *testing.T) {
+	o := Issuer{}
+	o.setDefaults()
+
+	if p, err := o.retryPolicyFor(&sampleissuerapi.IssuerSpec{}); p != nil || err != nil {
+		t.Errorf("expected no retry policy without retry settings, got %v, %v", p, err)
+	}
+
+	p, err := o.retryPolicyFor(&sampleissuerapi.IssuerSpec{
+		Retry: &sampleissuerapi.RetrySpec{MaxDuration: &metav1.Duration{Duration: time.Hour}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := retryPolicy{maxDuration: time.Hour, minBackoff: DefaultRequeueBaseDelay, maxBackoff: DefaultRequeueMaxDelay}
+	if *p != want {
+		t.Errorf("got %+v, want %+v", *p, want)
+	}
+
+	if _, err := o.retryPolicyFor(&sampleissuerapi.IssuerSpec{
+		Retry: &sampleissuerapi.RetrySpec{
+			MinBackoff: &metav1.Duration{Duration: time.Minute},
+			MaxBackoff: &metav1.Duration{Duration: time.Second},
+		},
+	}); err == nil {
+		t.Error("expected an error for a max backoff less than the min backoff")
+	}
+}
+
+func TestRetryPolicyErrorFor(t *testing.T) {
+	p := retryPolicy{maxDuration: time.Hour, minBackoff: time.Second, maxBackoff: 5 * time.Minute}
+	errUnavailable := errors.New("service unavailable")
+
+	tests := []struct {
+		name             string
+		err              error
+		age              time.Duration
+		wantPermanent    bool
+		wantPending      bool
+		wantRequeueAfter time.Duration
+	}{
+		{name: "new request", err: errUnavailable, age: 0, wantPending: true, wantRequeueAfter: time.Second},
+		{name: "backoff grows with age", err: errUnavailable, age: 2 * time.Minute, wantPending: true, wantRequeueAfter: 2 * time.Minute},
+		{name: "backoff is bounded", err: errUnavailable, age: 30 * time.Minute, wantPending: true, wantRequeueAfter: 5 * time.Minute},
+		{name: "past max duration", err: errUnavailable, age: time.Hour, wantPermanent: true},
+		{name: "permanent error", err: signer.PermanentError{Err: errUnavailable}, wantPermanent: true},
+		{name: "issuer error", err: signer.IssuerError{Err: errUnavailable}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := p.errorFor(tc.err, tc.age)
+			if !errors.Is(err, errUnavailable) {
+				t.Errorf("expected the error to be wrapped, got %v", err)
+			}
+			if got := errors.As(err, &signer.PermanentError{}); got != tc.wantPermanent {
+				t.Errorf("PermanentError: want %v, got %v", tc.wantPermanent, got)
+			}
+			var pending signer.PendingError
+			if got := errors.As(err, &pending); got != tc.wantPending {
+				t.Errorf("PendingError: want %v, got %v", tc.wantPending, got)
+			}
+			if pending.RequeueAfter != tc.wantRequeueAfter {
+				t.Errorf("RequeueAfter: want %s, got %s", tc.wantRequeueAfter, pending.RequeueAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+// secretIndexField is the name of the field index of issuers by the Secrets
+// they reference, as "<namespace>/<name>".
+const secretIndexField = ".spec.secretRefs"
+
+// referencedSecrets returns the Secrets referenced by an issuer spec, in the
+// namespace of the issuer, or the cluster resource namespace for a
+// SampleClusterIssuer.
+func referencedSecrets(issuerSpec *sampleissuerapi.IssuerSpec, namespace string) []string {
+	var secrets []string
+	if issuerSpec.AuthSecretName != "" {
+		secrets = append(secrets, types.NamespacedName{Namespace: namespace, Name: issuerSpec.AuthSecretName}.String())
+	}
+	if ref := issuerSpec.PrivateKeyPassphraseSecretRef; ref != nil && ref.Name != "" {
+		secrets = append(secrets, types.NamespacedName{Namespace: namespace, Name: ref.Name}.String())
+	}
+	return secrets
+}
+
+// indexSecrets is the index function of secretIndexField.
+func (o *Issuer) indexSecrets(obj client.Object) []string {
+	switch t := obj.(type) {
+	case *sampleissuerapi.SampleIssuer:
+		return referencedSecrets(&t.Spec, t.Namespace)
+	case *sampleissuerapi.SampleClusterIssuer:
+		return referencedSecrets(&t.Spec.IssuerSpec, o.ClusterResourceNamespace)
+	default:
+		return nil
+	}
+}
+
+// setupSecretWatch indexes the issuers of the controller for gvk by the
+// Secrets they reference, and watches Secrets, so that an issuer is checked
+// again as soon as one of its Secrets is created, updated or deleted. It is
+// called by issuer-lib for each of its controllers, and does nothing for the
+// CertificateRequest controllers.
+func (o *Issuer) setupSecretWatch(ctx context.Context, gvk schema.GroupVersionKind, mgr ctrl.Manager, b *builder.Builder) error {
+	var issuer client.Object
+	var list func(context.Context, *corev1.Secret) ([]reconcile.Request, error)
+	switch gvk {
+	case sampleissuerapi.SchemeGroupVersion.WithKind("SampleIssuer"):
+		issuer = &sampleissuerapi.SampleIssuer{}
+		list = o.sampleIssuersForSecret
+	case sampleissuerapi.SchemeGroupVersion.WithKind("SampleClusterIssuer"):
+		issuer = &sampleissuerapi.SampleClusterIssuer{}
+		list = o.sampleClusterIssuersForSecret
+	default:
+		return nil
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(ctx, issuer, secretIndexField, o.indexSecrets); err != nil {
+		return err
+	}
+
+	b.Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return nil
+		}
+		requests, err := list(ctx, secret)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "Failed to list the issuers referencing a Secret", "secret", client.ObjectKeyFromObject(secret))
+			return nil
+		}
+		return requests
+	}))
+	return nil
+}
+
+// sampleIssuersForSecret returns a request for each SampleIssuer which
+// references a Secret.
+func (o *Issuer) sampleIssuersForSecret(ctx context.Context, secret *corev1.Secret) ([]reconcile.Request, error) {
+	var issuers sampleissuerapi.SampleIssuerList
+	if err := o.client.List(ctx, &issuers,
+		client.InNamespace(secret.Namespace),
+		client.MatchingFields{secretIndexField: client.ObjectKeyFromObject(secret).String()},
+	); err != nil {
+		return nil, err
+	}
+
+	requests := make([]reconcile.Request, 0, len(issuers.Items))
+	for _, issuer := range issuers.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&issuer)})
+	}
+	return requests, nil
+}
+
+// sampleClusterIssuersForSecret returns a request for each
+// SampleClusterIssuer which references a Secret. Only Secrets in the cluster
+// resource namespace are referenced by SampleClusterIssuers.
+func (o *Issuer) sampleClusterIssuersForSecret(ctx context.Context, secret *corev1.Secret) ([]reconcile.Request, error) {
+	if secret.Namespace != o.ClusterResourceNamespace {
+		return nil, nil
+	}
+
+	var issuers sampleissuerapi.SampleClusterIssuerList
+	if err := o.client.List(ctx, &issuers,
+		client.MatchingFields{secretIndexField: client.ObjectKeyFromObject(secret).String()},
+	); err != nil {
+		return nil, err
+	}
+
+	requests := make([]reconcile.Request, 0, len(issuers.Items))
+	for _, issuer := range issuers.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&issuer)})
+	}
+	return requests, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+func TestIssuersForSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := sampleissuerapi.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	o := &Issuer{ClusterResourceNamespace: "cert-manager"}
+	o.client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&sampleissuerapi.SampleIssuer{}, secretIndexField, o.indexSecrets).
+		WithIndex(&sampleissuerapi.SampleClusterIssuer{}, secretIndexField, o.indexSecrets).
+		WithObjects(
+			&sampleissuerapi.SampleIssuer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "auth"},
+				Spec:       sampleissuerapi.IssuerSpec{AuthSecretName: "credentials"},
+			},
+			&sampleissuerapi.SampleIssuer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "passphrase"},
+				Spec: sampleissuerapi.IssuerSpec{
+					AuthSecretName: "other",
+					PrivateKeyPassphraseSecretRef: &sampleissuerapi.SecretKeySelector{
+						Name: "credentials",
+						Key:  "passphrase",
+					},
+				},
+			},
+			&sampleissuerapi.SampleIssuer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "auth"},
+				Spec:       sampleissuerapi.IssuerSpec{AuthSecretName: "credentials"},
+			},
+			&sampleissuerapi.SampleClusterIssuer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: sampleissuerapi.ClusterIssuerSpec{
+					IssuerSpec: sampleissuerapi.IssuerSpec{AuthSecretName: "credentials"},
+				},
+			},
+		).
+		Build()
+
+	secret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	request := func(namespace, name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+
+	tests := []struct {
+		name   string
+		secret *corev1.Secret
+		list   func(context.Context, *corev1.Secret) ([]reconcile.Request, error)
+		want   []reconcile.Request
+	}{
+		{
+			name:   "issuers referencing the secret in their namespace",
+			secret: secret("ns1", "credentials"),
+			list:   o.sampleIssuersForSecret,
+			want:   []reconcile.Request{request("ns1", "auth"), request("ns1", "passphrase")},
+		},
+		{
+			name:   "no issuer references the secret",
+			secret: secret("ns1", "unused"),
+			list:   o.sampleIssuersForSecret,
+			want:   []reconcile.Request{},
+		},
+		{
+			name:   "cluster issuer referencing the secret in the cluster resource namespace",
+			secret: secret("cert-manager", "credentials"),
+			list:   o.sampleClusterIssuersForSecret,
+			want:   []reconcile.Request{request("", "cluster")},
+		},
+		{
+			name:   "cluster issuers ignore secrets in other namespaces",
+			secret: secret("ns1", "credentials"),
+			list:   o.sampleClusterIssuersForSecret,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.list(t.Context(), tc.secret)
+			if err != nil {
+				t.Fatal(err)
+			}
+			slices.SortFunc(got, func(a, b reconcile.Request) int {
+				return strings.Compare(a.String(), b.String())
+			})
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
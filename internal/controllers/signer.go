@@ -21,22 +21,49 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	issuerapi "github.com/cert-manager/issuer-lib/api/v1alpha1"
 	"github.com/cert-manager/issuer-lib/controllers"
 	"github.com/cert-manager/issuer-lib/controllers/signer"
+	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/celpolicy"
+	"github.com/cert-manager/sample-external-issuer/internal/x509policy"
+)
+
+// PassphraseKey is the key in the secret data passed to the builders which
+// holds the passphrase of an encrypted CA private key. It is read from the
+// auth Secret, or from the Secret referenced by PrivateKeyPassphraseSecretRef
+// if that is set.
+const PassphraseKey = "passphrase"
+
+const (
+	// eventReasonExtensionsDropped is the reason of the event recorded when
+	// extensions of a CSR are not added to the certificate.
+	eventReasonExtensionsDropped = "ExtensionsDropped"
+	eventActionSign              = "Sign"
 )
 
 var (
 	errGetAuthSecret        = errors.New("failed to get Secret containing Issuer credentials")
+	errGetPassphraseSecret  = errors.New("failed to get Secret containing the private key passphrase")
 	errHealthCheckerBuilder = errors.New("failed to build the healthchecker")
 	errHealthCheckerCheck   = errors.New("healthcheck failed")
 
@@ -44,30 +71,135 @@ var (
 	errSignerSign    = errors.New("failed to sign")
 )
 
+// HealthCheckResult describes the signing service of an issuer, as found by
+// a successful health check. It is recorded in the status of the issuer.
+type HealthCheckResult struct {
+	// CA is the CA certificate which signs the certificates of the issuer.
+	// It is nil if the signing service does not report it.
+	CA *x509.Certificate
+	// BackendVersion is the version of the signing service, if it reports
+	// one.
+	BackendVersion string
+}
+
+// HealthChecker checks the signing service of an issuer. Check may return a
+// result together with a HealthCheckError which is a warning.
 type HealthChecker interface {
-	Check() error
+	Check(context.Context) (*HealthCheckResult, error)
 }
 
 type HealthCheckerBuilder func(*sampleissuerapi.IssuerSpec, map[string][]byte) (HealthChecker, error)
 
+// SignResult is the result of a Signer. All certificates are PEM encoded.
+type SignResult struct {
+	// Leaf is the signed certificate.
+	Leaf []byte
+	// Chain are the intermediate CA certificates which chain the leaf to the
+	// root, starting with the issuer of the leaf.
+	Chain []byte
+	// Root is the self-signed root CA certificate of the chain. It is empty if
+	// the root is not known.
+	Root []byte
+	// DroppedExtensions are the OIDs of the extensions of the CSR which were
+	// not allowed by the issuer policy, and are not in the certificate.
+	DroppedExtensions []string
+}
+
 type Signer interface {
-	Sign(*x509.Certificate) ([]byte, error)
+	Sign(context.Context, *x509.Certificate) (*SignResult, error)
 }
 
 type SignerBuilder func(*sampleissuerapi.IssuerSpec, map[string][]byte) (Signer, error)
 
+// SignFailurePolicy decides which errors returned by a Signer are reported as
+// IssuerErrors, which make the issuer not Ready until it is checked again.
+type SignFailurePolicy string
+
+const (
+	// SignFailurePolicyCredentials degrades the issuer when the signing
+	// service rejects the URL or the credentials of the issuer.
+	SignFailurePolicyCredentials SignFailurePolicy = "Credentials"
+	// SignFailurePolicyNever never degrades the issuer. Only Check changes
+	// the Ready condition of the issuer.
+	SignFailurePolicyNever SignFailurePolicy = "Never"
+	// SignFailurePolicyUnavailable also degrades the issuer when the signing
+	// service cannot be reached or responds with a server error.
+	SignFailurePolicyUnavailable SignFailurePolicy = "Unavailable"
+)
+
+// SignFailurePolicies are the valid SignFailurePolicies.
+var SignFailurePolicies = []SignFailurePolicy{
+	SignFailurePolicyCredentials,
+	SignFailurePolicyNever,
+	SignFailurePolicyUnavailable,
+}
+
+// StatusError is returned by a Signer when the signing service responds with
+// an HTTP error status. Sign uses the status code to decide whether the
+// request is retried, failed permanently or reported as an issuer error.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("signing service responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+// PolicyError is returned by a Signer when a request is denied by the signing
+// policy of the issuer. Sign fails the request permanently.
+type PolicyError struct {
+	Err error
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("denied by the issuer policy: %v", e.Err)
+}
+
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
 type Issuer struct {
 	HealthCheckerBuilder     HealthCheckerBuilder
 	SignerBuilder            SignerBuilder
 	ClusterResourceNamespace string
-
-	client client.Client
+	// SignFailurePolicy decides which errors of a Signer make the issuer not
+	// Ready. The default is SignFailurePolicyCredentials.
+	SignFailurePolicy SignFailurePolicy
+
+	// MaxRetryDuration is how long after its creation a request is retried
+	// before it is failed. Defaults to DefaultMaxRetryDuration.
+	MaxRetryDuration time.Duration
+	// RequeueBaseDelay and RequeueMaxDelay bound the exponential backoff of
+	// failed reconciles. Default to DefaultRequeueBaseDelay and
+	// DefaultRequeueMaxDelay.
+	RequeueBaseDelay time.Duration
+	RequeueMaxDelay  time.Duration
+	// FieldOwner is the field manager used to apply the status of issuers and
+	// requests. Defaults to DefaultFieldOwner.
+	FieldOwner string
+	// EventSource is the name of the event recorder. Defaults to FieldOwner.
+	EventSource string
+	// AuthorizeRequesters checks with a SubjectAccessReview that the user
+	// which created a request may use the issuer. The CertificateRequests of
+	// Certificates are created by cert-manager, so their requester is the
+	// ServiceAccount of cert-manager, which must be allowed to use the
+	// issuers of Certificates.
+	AuthorizeRequesters bool
+
+	client   client.Client
+	recorder events.EventRecorder
+	celRules *celpolicy.Cache
+	signers  *signerCache
 }
 
 // +kubebuilder:rbac:groups=sample-issuer.example.com,resources=sampleclusterissuers;sampleissuers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=sample-issuer.example.com,resources=sampleclusterissuers/status;sampleissuers/status,verbs=patch
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cert-manager.io,resources=certificaterequests/status,verbs=patch
 // +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests,verbs=get;list;watch
@@ -75,27 +207,57 @@ type Issuer struct {
 // +kubebuilder:rbac:groups=certificates.k8s.io,resources=signers,verbs=sign,resourceNames=sampleclusterissuers.sample-issuer.example.com/*;sampleissuers.sample-issuer.example.com/*
 
 func (s Issuer) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	s.setDefaults()
+	if err := s.validate(); err != nil {
+		return err
+	}
+
 	s.client = mgr.GetClient()
+	s.recorder = mgr.GetEventRecorder(s.EventSource)
+	s.celRules = celpolicy.NewCache()
+	s.signers = newSignerCache()
 
 	return (&controllers.CombinedController{
 		IssuerTypes:        []issuerapi.Issuer{&sampleissuerapi.SampleIssuer{}},
 		ClusterIssuerTypes: []issuerapi.Issuer{&sampleissuerapi.SampleClusterIssuer{}},
 
-		FieldOwner:       "sampleissuer.cert-manager.io",
-		MaxRetryDuration: 1 * time.Minute,
+		FieldOwner:       s.FieldOwner,
+		MaxRetryDuration: s.MaxRetryDuration,
+
+		// Set the CA of CertificateRequests, so that ca.crt in the Secret of
+		// a Certificate is the root of the chain.
+		SetCAOnCertificateRequest: true,
 
 		Sign:          s.Sign,
 		Check:         s.Check,
-		EventRecorder: mgr.GetEventRecorder("sampleissuer.cert-manager.io"),
+		EventRecorder: s.recorder,
+
+		PreSetupWithManager: s.preSetupWithManager,
+		ControllerOptions:   s.controllerOptions(),
 	}).SetupWithManager(ctx, mgr)
 }
 
+// preSetupWithManager is called by issuer-lib before each of its controllers
+// is built.
+func (o *Issuer) preSetupWithManager(ctx context.Context, gvk schema.GroupVersionKind, mgr ctrl.Manager, b *builder.Builder) error {
+	// issuer-lib only passes its ControllerOptions to the issuer
+	// controllers, so they are set on the request controllers here. They
+	// are set on every controller, so that each gets its own rate limiter
+	// instead of sharing the one in the ControllerOptions.
+	b.WithOptions(o.controllerOptions())
+
+	o.setupIssuerCleanup(gvk, b)
+
+	// Check issuers again when the Secrets they reference change.
+	return o.setupSecretWatch(ctx, gvk, mgr, b)
+}
+
 func (o *Issuer) getIssuerDetails(issuerObject issuerapi.Issuer) (*sampleissuerapi.IssuerSpec, string, error) {
 	switch t := issuerObject.(type) {
 	case *sampleissuerapi.SampleIssuer:
 		return &t.Spec, issuerObject.GetNamespace(), nil
 	case *sampleissuerapi.SampleClusterIssuer:
-		return &t.Spec, o.ClusterResourceNamespace, nil
+		return &t.Spec.IssuerSpec, o.ClusterResourceNamespace, nil
 	default:
 		// A permanent error will cause the Issuer to not retry until the
 		// Issuer is updated.
@@ -105,7 +267,123 @@ func (o *Issuer) getIssuerDetails(issuerObject issuerapi.Issuer) (*sampleissuera
 	}
 }
 
-func (o *Issuer) getSecretData(ctx context.Context, issuerSpec *sampleissuerapi.IssuerSpec, namespace string) (map[string][]byte, error) {
+// getCELRules returns the compiled CEL rules of the issuer policy, or nil if
+// the policy has no rules.
+func (o *Issuer) getCELRules(issuerObject issuerapi.Issuer, issuerSpec *sampleissuerapi.IssuerSpec) (*celpolicy.Rules, error) {
+	if issuerSpec.Policy == nil || len(issuerSpec.Policy.CELRules) == 0 {
+		return nil, nil
+	}
+	if o.celRules == nil {
+		return celpolicy.Compile(issuerSpec.Policy.CELRules)
+	}
+	return o.celRules.Get(issuerObject.GetUID(), issuerObject.GetGeneration(), issuerSpec.Policy.CELRules)
+}
+
+// getRequestMetadata returns the metadata of a CertificateRequest or
+// CertificateSigningRequest which is available to CEL rules.
+func (o *Issuer) getRequestMetadata(ctx context.Context, cr signer.CertificateRequestObject) (celpolicy.Request, error) {
+	req := celpolicy.Request{
+		Namespace:   cr.GetNamespace(),
+		Annotations: cr.GetAnnotations(),
+	}
+
+	if obj, ok := cr.(runtime.Object); ok {
+		switch t := obj.DeepCopyObject().(type) {
+		case *cmapi.CertificateRequest:
+			req.Username = t.Spec.Username
+			req.Groups = t.Spec.Groups
+		case *certificatesv1.CertificateSigningRequest:
+			req.Username = t.Spec.Username
+			req.Groups = t.Spec.Groups
+		}
+	}
+
+	if req.Namespace != "" {
+		var namespace corev1.Namespace
+		if err := o.client.Get(ctx, types.NamespacedName{Name: req.Namespace}, &namespace); err != nil {
+			return req, fmt.Errorf("failed to get namespace %s: %v", req.Namespace, err)
+		}
+		req.NamespaceLabels = namespace.Labels
+	}
+
+	return req, nil
+}
+
+// requesterFor returns the user which created a CertificateRequest or
+// CertificateSigningRequest.
+func requesterFor(cr signer.CertificateRequestObject) requester {
+	var user requester
+	if obj, ok := cr.(runtime.Object); ok {
+		switch t := obj.DeepCopyObject().(type) {
+		case *cmapi.CertificateRequest:
+			user = requester{
+				Username: t.Spec.Username,
+				UID:      t.Spec.UID,
+				Groups:   t.Spec.Groups,
+				Extra:    t.Spec.Extra,
+			}
+		case *certificatesv1.CertificateSigningRequest:
+			user = requester{
+				Username: t.Spec.Username,
+				UID:      t.Spec.UID,
+				Groups:   t.Spec.Groups,
+			}
+			if t.Spec.Extra != nil {
+				user.Extra = make(map[string][]string, len(t.Spec.Extra))
+				for k, v := range t.Spec.Extra {
+					user.Extra[k] = v
+				}
+			}
+		}
+	}
+	return user
+}
+
+// requestsDuration returns true if a CertificateRequest or
+// CertificateSigningRequest asks for a duration. Otherwise the duration of the
+// certificate details is the default duration of cert-manager.
+func requestsDuration(cr signer.CertificateRequestObject) bool {
+	obj, ok := cr.(runtime.Object)
+	if !ok {
+		return true
+	}
+
+	switch t := obj.DeepCopyObject().(type) {
+	case *cmapi.CertificateRequest:
+		return t.Spec.Duration != nil
+	case *certificatesv1.CertificateSigningRequest:
+		if t.Spec.ExpirationSeconds != nil {
+			return true
+		}
+		_, ok := t.Annotations[experimentalapi.CertificateSigningRequestDurationAnnotationKey]
+		return ok
+	default:
+		return true
+	}
+}
+
+// requestedUsages returns the usages listed in a CertificateRequest or
+// CertificateSigningRequest.
+func requestedUsages(cr signer.CertificateRequestObject) []x509policy.KeyUsage {
+	obj, ok := cr.(runtime.Object)
+	if !ok {
+		return nil
+	}
+
+	switch t := obj.DeepCopyObject().(type) {
+	case *cmapi.CertificateRequest:
+		return x509policy.KeyUsagesFrom(t.Spec.Usages)
+	case *certificatesv1.CertificateSigningRequest:
+		return x509policy.KeyUsagesFrom(t.Spec.Usages)
+	default:
+		return nil
+	}
+}
+
+// getSecretData returns the data of the auth Secret of an issuer, and of the
+// passphrase Secret if one is referenced. The returned version changes
+// whenever one of the Secrets changes.
+func (o *Issuer) getSecretData(ctx context.Context, issuerSpec *sampleissuerapi.IssuerSpec, namespace string) (map[string][]byte, string, error) {
 	secretName := types.NamespacedName{
 		Namespace: namespace,
 		Name:      issuerSpec.AuthSecretName,
@@ -113,31 +391,120 @@ func (o *Issuer) getSecretData(ctx context.Context, issuerSpec *sampleissuerapi.
 
 	var secret corev1.Secret
 	if err := o.client.Get(ctx, secretName, &secret); err != nil {
-		return nil, fmt.Errorf("%w, secret name: %s, reason: %v", errGetAuthSecret, secretName, err)
+		return nil, "", fmt.Errorf("%w, secret name: %s, reason: %v", errGetAuthSecret, secretName, err)
+	}
+
+	ref := issuerSpec.PrivateKeyPassphraseSecretRef
+	if ref == nil {
+		return secret.Data, secret.ResourceVersion, nil
 	}
 
-	checker, err := o.HealthCheckerBuilder(issuerSpec, secret.Data)
+	passphraseSecretName := types.NamespacedName{
+		Namespace: namespace,
+		Name:      ref.Name,
+	}
+
+	var passphraseSecret corev1.Secret
+	if err := o.client.Get(ctx, passphraseSecretName, &passphraseSecret); err != nil {
+		return nil, "", fmt.Errorf("%w, secret name: %s, reason: %v", errGetPassphraseSecret, passphraseSecretName, err)
+	}
+
+	passphrase, ok := passphraseSecret.Data[ref.Key]
+	if !ok {
+		return nil, "", fmt.Errorf("%w, secret name: %s, reason: secret does not contain key %q", errGetPassphraseSecret, passphraseSecretName, ref.Key)
+	}
+
+	// Copy the data, so that the Secret in the informer cache is not modified.
+	secretData := maps.Clone(secret.Data)
+	if secretData == nil {
+		secretData = map[string][]byte{}
+	}
+	secretData[PassphraseKey] = passphrase
+
+	return secretData, secret.ResourceVersion + "/" + passphraseSecret.ResourceVersion, nil
+}
+
+// signerCacheKeyFor returns the key of the HealthChecker and Signer built for
+// an issuer from Secrets with the given version.
+func signerCacheKeyFor(issuerObject issuerapi.Issuer, secretVersion string) signerCacheKey {
+	return signerCacheKey{
+		uid:           issuerObject.GetUID(),
+		generation:    issuerObject.GetGeneration(),
+		secretVersion: secretVersion,
+	}
+}
+
+// checkHealth gets or builds a HealthChecker and runs it. The error of the
+// HealthChecker is returned as is, so that a HealthCheckError can be
+// inspected by the caller.
+func (o *Issuer) checkHealth(ctx context.Context, key signerCacheKey, issuerSpec *sampleissuerapi.IssuerSpec, secretData map[string][]byte) (*HealthCheckResult, error) {
+	build := func() (HealthChecker, error) {
+		return o.HealthCheckerBuilder(issuerSpec, secretData)
+	}
+
+	var checker HealthChecker
+	var err error
+	if o.signers == nil {
+		checker, err = build()
+	} else {
+		checker, err = o.signers.getHealthChecker(key, build)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", errHealthCheckerBuilder, err)
 	}
 
-	if err := checker.Check(); err != nil {
-		return nil, fmt.Errorf("%w: %v", errHealthCheckerCheck, err)
-	}
+	return checker.Check(ctx)
+}
 
-	return secret.Data, nil
+// getSigner gets or builds the Signer of an issuer.
+func (o *Issuer) getSigner(key signerCacheKey, issuerSpec *sampleissuerapi.IssuerSpec, secretData map[string][]byte) (Signer, error) {
+	build := func() (Signer, error) {
+		return o.SignerBuilder(issuerSpec, secretData)
+	}
+	if o.signers == nil {
+		return build()
+	}
+	return o.signers.getSigner(key, build)
 }
 
 // Check checks that the CA it is available. Certificate requests will not be
 // processed until this check passes.
+// The result of the health check is also recorded in the Healthy condition of
+// the issuer, using the reason of a HealthCheckError if one is returned, and
+// the CA and the time of a successful check in the status of the issuer.
 func (o *Issuer) Check(ctx context.Context, issuerObject issuerapi.Issuer) error {
 	issuerSpec, namespace, err := o.getIssuerDetails(issuerObject)
 	if err != nil {
 		return err
 	}
 
-	_, err = o.getSecretData(ctx, issuerSpec, namespace)
-	return err
+	if _, err := o.getCELRules(issuerObject, issuerSpec); err != nil {
+		// The rules will not compile until the issuer is updated.
+		return signer.PermanentError{Err: err}
+	}
+
+	if _, err := o.retryPolicyFor(issuerSpec); err != nil {
+		return signer.PermanentError{Err: err}
+	}
+
+	if clusterIssuer, ok := issuerObject.(*sampleissuerapi.SampleClusterIssuer); ok {
+		if _, err := namespaceSelectorFor(&clusterIssuer.Spec); err != nil {
+			return signer.PermanentError{Err: err}
+		}
+	}
+
+	secretData, secretVersion, err := o.getSecretData(ctx, issuerSpec, namespace)
+	if err != nil {
+		return err
+	}
+
+	result, checkErr := o.checkHealth(ctx, signerCacheKeyFor(issuerObject, secretVersion), issuerSpec, secretData)
+	recordHealthCheck(issuerObject, result, checkErr)
+	if err := o.setHealthStatus(ctx, issuerObject, result, checkErr); err != nil {
+		return err
+	}
+
+	return healthCheckErrorFor(checkErr)
 }
 
 // Sign returns a signed certificate for the supplied CertificateRequestObject (a cert-manager CertificateRequest resource or
@@ -146,7 +513,12 @@ func (o *Issuer) Check(ctx context.Context, issuerObject issuerapi.Issuer) error
 // The Sign method should return a PEMBundle containing the signed certificate and any intermediate certificates (see the PEMBundle docs for more information).
 // If the Sign method returns an error, the issuance will be retried until the MaxRetryDuration is reached.
 // Special errors and cases can be found in the issuer-lib README: https://github.com/cert-manager/issuer-lib/tree/main?tab=readme-ov-file#how-it-works
-func (o *Issuer) Sign(ctx context.Context, cr signer.CertificateRequestObject, issuerObject issuerapi.Issuer) (signer.PEMBundle, error) {
+func (o *Issuer) Sign(ctx context.Context, cr signer.CertificateRequestObject, issuerObject issuerapi.Issuer) (_ signer.PEMBundle, err error) {
+	start := time.Now()
+	defer func() {
+		recordSign(issuerObject, signResultFor(err), time.Since(start))
+	}()
+
 	issuerSpec, namespace, err := o.getIssuerDetails(issuerObject)
 	if err != nil {
 		// Returning an IssuerError will change the status of the Issuer to Failed too.
@@ -155,38 +527,203 @@ func (o *Issuer) Sign(ctx context.Context, cr signer.CertificateRequestObject, i
 		}
 	}
 
-	secretData, err := o.getSecretData(ctx, issuerSpec, namespace)
+	bundle, err := o.sign(ctx, cr, issuerObject, issuerSpec, namespace)
+	if err == nil {
+		return bundle, nil
+	}
+
+	// Retryable errors are retried according to the retry settings of the
+	// issuer, if it has any, instead of the MaxRetryDuration of issuer-lib.
+	retry, retryErr := o.retryPolicyFor(issuerSpec)
+	if retryErr != nil {
+		return signer.PEMBundle{}, signer.IssuerError{Err: retryErr}
+	}
+	if retry != nil {
+		err = retry.errorFor(err, time.Since(cr.GetCreationTimestamp().Time))
+	}
+	return signer.PEMBundle{}, err
+}
+
+// sign signs a request for Sign.
+func (o *Issuer) sign(ctx context.Context, cr signer.CertificateRequestObject, issuerObject issuerapi.Issuer, issuerSpec *sampleissuerapi.IssuerSpec, namespace string) (signer.PEMBundle, error) {
+	// A SampleClusterIssuer may only be used by requests from the namespaces
+	// it allows.
+	if clusterIssuer, ok := issuerObject.(*sampleissuerapi.SampleClusterIssuer); ok {
+		if err := o.checkNamespace(ctx, clusterIssuer, cr.GetNamespace()); err != nil {
+			var policyErr *PolicyError
+			if errors.As(err, &policyErr) {
+				return signer.PEMBundle{}, signer.PermanentError{Err: err}
+			}
+			return signer.PEMBundle{}, err
+		}
+	}
+
+	// The user which created the request must be allowed to use the issuer.
+	if o.AuthorizeRequesters {
+		if err := o.authorizeRequester(ctx, requesterFor(cr), issuerObject); err != nil {
+			if errors.Is(err, errRequesterNotAllowed) {
+				return signer.PEMBundle{}, signer.PermanentError{Err: err}
+			}
+			return signer.PEMBundle{}, err
+		}
+	}
+
+	secretData, secretVersion, err := o.getSecretData(ctx, issuerSpec, namespace)
 	if err != nil {
 		// Returning an IssuerError will change the status of the Issuer to Failed too.
 		return signer.PEMBundle{}, signer.IssuerError{
 			Err: err,
 		}
 	}
+	key := signerCacheKeyFor(issuerObject, secretVersion)
+
+	// The health of the issuer is not checked here. issuer-lib only calls Sign
+	// for issuers which are Ready, which is the result of the last Check.
 
 	certDetails, err := cr.GetCertificateDetails()
 	if err != nil {
 		return signer.PEMBundle{}, err
 	}
 
+	if issuerSpec.DefaultDuration != nil && !requestsDuration(cr) {
+		certDetails.Duration = issuerSpec.DefaultDuration.Duration
+	}
+
+	// The requested usages are translated with the table of x509policy,
+	// which the signing policy also uses for the allowed usages of the
+	// issuer. issuer-lib adds cert sign to the usages of CA requests, which
+	// is kept.
+	if usages := requestedUsages(cr); len(usages) > 0 {
+		keyUsage, extKeyUsages, err := x509policy.KeyUsagesFromStrings(usages)
+		if err != nil {
+			return signer.PEMBundle{}, signer.PermanentError{Err: err}
+		}
+		certDetails.KeyUsage = keyUsage | certDetails.KeyUsage&x509.KeyUsageCertSign
+		certDetails.ExtKeyUsage = extKeyUsages
+	}
+
 	certTemplate, err := certDetails.CertificateTemplate()
 	if err != nil {
 		return signer.PEMBundle{}, err
 	}
 
-	signerObj, err := o.SignerBuilder(issuerSpec, secretData)
+	// The extensions of the CSR are passed to the Signer in Extensions. The
+	// signing policy decides which of them are added to the certificate.
+	csr, err := pki.DecodeX509CertificateRequestBytes(certDetails.CSR)
 	if err != nil {
-		return signer.PEMBundle{}, fmt.Errorf("%w: %v", errSignerBuilder, err)
+		return signer.PEMBundle{}, err
 	}
+	certTemplate.Extensions = csr.Extensions
 
-	signed, err := signerObj.Sign(certTemplate)
+	celRules, err := o.getCELRules(issuerObject, issuerSpec)
 	if err != nil {
-		return signer.PEMBundle{}, fmt.Errorf("%w: %v", errSignerSign, err)
+		return signer.PEMBundle{}, signer.IssuerError{Err: err}
+	}
+	if celRules != nil {
+		req, err := o.getRequestMetadata(ctx, cr)
+		if err != nil {
+			return signer.PEMBundle{}, err
+		}
+		if err := celRules.Evaluate(ctx, certTemplate, req); err != nil {
+			return signer.PEMBundle{}, signer.PermanentError{Err: &PolicyError{Err: err}}
+		}
 	}
 
-	bundle, err := pki.ParseSingleCertificateChainPEM(signed)
+	signerObj, err := o.getSigner(key, issuerSpec, secretData)
+	if err != nil {
+		// The signer is built from the issuer and its Secrets, for example an
+		// encrypted CA key which cannot be decrypted, so this is an issuer error.
+		return signer.PEMBundle{}, signer.IssuerError{
+			Err: fmt.Errorf("%w: %v", errSignerBuilder, err),
+		}
+	}
+
+	signed, err := signerObj.Sign(ctx, certTemplate)
+	if err != nil {
+		return signer.PEMBundle{}, signErrorFor(err, o.SignFailurePolicy)
+	}
+
+	if len(signed.DroppedExtensions) > 0 {
+		o.recordDroppedExtensions(cr, signed.DroppedExtensions)
+	}
+
+	// The chain is verified and split into the leaf and intermediates, in
+	// ChainPEM, and the root, in CAPEM. If the root is not known, CAPEM is the
+	// last intermediate.
+	bundle, err := pki.ParseSingleCertificateChainPEM(slices.Concat(signed.Leaf, signed.Chain, signed.Root))
 	if err != nil {
 		return signer.PEMBundle{}, err
 	}
 
 	return signer.PEMBundle(bundle), nil
 }
+
+// recordDroppedExtensions records an event on a CertificateRequest or
+// CertificateSigningRequest listing the extensions of its CSR which were
+// dropped.
+func (o *Issuer) recordDroppedExtensions(cr signer.CertificateRequestObject, oids []string) {
+	obj, ok := cr.(runtime.Object)
+	if !ok || o.recorder == nil {
+		return
+	}
+
+	// The event is recorded on the underlying resource, which is registered
+	// in the scheme.
+	o.recorder.Eventf(obj.DeepCopyObject(), nil, corev1.EventTypeWarning, eventReasonExtensionsDropped, eventActionSign,
+		"Extensions not allowed by the issuer policy were dropped: %s", strings.Join(oids, ", "))
+}
+
+// signResultFor returns the result of Sign which is recorded in the metrics
+// for the error it returned.
+func signResultFor(err error) string {
+	switch {
+	case err == nil:
+		return signResultSuccess
+	case errors.As(err, &signer.PermanentError{}):
+		return signResultPermanent
+	case errors.As(err, &signer.IssuerError{}):
+		return signResultIssuer
+	default:
+		return signResultRetriable
+	}
+}
+
+// signErrorFor wraps an error returned by a Signer. Errors caused by the
+// request itself fail the request permanently, errors which policy attributes
+// to the issuer are reported as IssuerErrors and everything else is retried.
+func signErrorFor(err error, policy SignFailurePolicy) error {
+	wrapped := fmt.Errorf("%w: %v", errSignerSign, err)
+
+	var policyErr *PolicyError
+	if errors.As(err, &policyErr) {
+		// The request will be denied until the policy of the issuer changes.
+		return signer.PermanentError{Err: wrapped}
+	}
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		// The Signer got no response, for example because the signing
+		// service could not be reached.
+		if policy == SignFailurePolicyUnavailable {
+			return signer.IssuerError{Err: wrapped}
+		}
+		return wrapped
+	}
+
+	switch statusErr.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		// The signing service will never accept this request.
+		return signer.PermanentError{Err: wrapped}
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		// The URL or the credentials of the issuer are wrong.
+		if policy == SignFailurePolicyNever {
+			return wrapped
+		}
+		return signer.IssuerError{Err: wrapped}
+	}
+
+	if statusErr.StatusCode >= http.StatusInternalServerError && policy == SignFailurePolicyUnavailable {
+		return signer.IssuerError{Err: wrapped}
+	}
+	return wrapped
+}
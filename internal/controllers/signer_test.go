@@ -0,0 +1,299 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/issuer-lib/controllers/signer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+func TestSignErrorFor(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		policy        SignFailurePolicy
+		wantPermanent bool
+		wantIssuer    bool
+	}{
+		{name: "plain error", err: errors.New("connection refused")},
+		{name: "bad request", err: &StatusError{StatusCode: http.StatusBadRequest}, wantPermanent: true},
+		{name: "unprocessable entity", err: &StatusError{StatusCode: http.StatusUnprocessableEntity}, wantPermanent: true},
+		{name: "unauthorized", err: &StatusError{StatusCode: http.StatusUnauthorized}, wantIssuer: true},
+		{name: "forbidden", err: &StatusError{StatusCode: http.StatusForbidden}, wantIssuer: true},
+		{name: "not found", err: &StatusError{StatusCode: http.StatusNotFound}, wantIssuer: true},
+		{name: "too many requests", err: &StatusError{StatusCode: http.StatusTooManyRequests}},
+		{name: "internal server error", err: &StatusError{StatusCode: http.StatusInternalServerError}},
+		{name: "policy denied", err: &PolicyError{Err: errors.New("DNS name not allowed")}, wantPermanent: true},
+		{name: "credentials policy", err: &StatusError{StatusCode: http.StatusForbidden}, policy: SignFailurePolicyCredentials, wantIssuer: true},
+		{name: "credentials policy, plain error", err: errors.New("connection refused"), policy: SignFailurePolicyCredentials},
+		{name: "credentials policy, internal server error", err: &StatusError{StatusCode: http.StatusInternalServerError}, policy: SignFailurePolicyCredentials},
+		{name: "never policy, unauthorized", err: &StatusError{StatusCode: http.StatusUnauthorized}, policy: SignFailurePolicyNever},
+		{name: "never policy, forbidden", err: &StatusError{StatusCode: http.StatusForbidden}, policy: SignFailurePolicyNever},
+		{name: "never policy, not found", err: &StatusError{StatusCode: http.StatusNotFound}, policy: SignFailurePolicyNever},
+		{name: "never policy, plain error", err: errors.New("connection refused"), policy: SignFailurePolicyNever},
+		{name: "never policy, internal server error", err: &StatusError{StatusCode: http.StatusInternalServerError}, policy: SignFailurePolicyNever},
+		{name: "never policy, bad request", err: &StatusError{StatusCode: http.StatusBadRequest}, policy: SignFailurePolicyNever, wantPermanent: true},
+		{name: "unavailable policy, plain error", err: errors.New("connection refused"), policy: SignFailurePolicyUnavailable, wantIssuer: true},
+		{name: "unavailable policy, internal server error", err: &StatusError{StatusCode: http.StatusInternalServerError}, policy: SignFailurePolicyUnavailable, wantIssuer: true},
+		{name: "unavailable policy, bad gateway", err: &StatusError{StatusCode: http.StatusBadGateway}, policy: SignFailurePolicyUnavailable, wantIssuer: true},
+		{name: "unavailable policy, unauthorized", err: &StatusError{StatusCode: http.StatusUnauthorized}, policy: SignFailurePolicyUnavailable, wantIssuer: true},
+		{name: "unavailable policy, not found", err: &StatusError{StatusCode: http.StatusNotFound}, policy: SignFailurePolicyUnavailable, wantIssuer: true},
+		{name: "unavailable policy, too many requests", err: &StatusError{StatusCode: http.StatusTooManyRequests}, policy: SignFailurePolicyUnavailable},
+		{name: "unavailable policy, policy denied", err: &PolicyError{Err: errors.New("DNS name not allowed")}, policy: SignFailurePolicyUnavailable, wantPermanent: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := signErrorFor(tc.err, tc.policy)
+			if !errors.Is(err, errSignerSign) {
+				t.Errorf("expected error to wrap errSignerSign, got %v", err)
+			}
+			if got := errors.As(err, &signer.PermanentError{}); got != tc.wantPermanent {
+				t.Errorf("PermanentError: want %v, got %v", tc.wantPermanent, got)
+			}
+			if got := errors.As(err, &signer.IssuerError{}); got != tc.wantIssuer {
+				t.Errorf("IssuerError: want %v, got %v", tc.wantIssuer, got)
+			}
+		})
+	}
+}
+
+func TestSignResultFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "success", want: signResultSuccess},
+		{name: "permanent error", err: signer.PermanentError{Err: errors.New("denied")}, want: signResultPermanent},
+		{name: "issuer error", err: signer.IssuerError{Err: errors.New("invalid credentials")}, want: signResultIssuer},
+		{name: "pending error", err: signer.PendingError{Err: errors.New("unavailable")}, want: signResultRetriable},
+		{name: "plain error", err: errors.New("connection refused"), want: signResultRetriable},
+		{name: "wrapped signer error", err: signErrorFor(&StatusError{StatusCode: http.StatusBadRequest}, SignFailurePolicyCredentials), want: signResultPermanent},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := signResultFor(tc.err); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+// templateSigner signs the certificate templates passed to Sign with a CA,
+// and remembers the last one.
+type templateSigner struct {
+	caCert   *x509.Certificate
+	caKey    crypto.Signer
+	dropped  []string
+	err      error
+	template *x509.Certificate
+}
+
+func (s *templateSigner) Sign(_ context.Context, certTemplate *x509.Certificate) (*SignResult, error) {
+	s.template = certTemplate
+	if s.err != nil {
+		return nil, s.err
+	}
+	certTemplate.SerialNumber = big.NewInt(2)
+	der, err := x509.CreateCertificate(rand.Reader, certTemplate, s.caCert, certTemplate.PublicKey, s.caKey)
+	if err != nil {
+		return nil, err
+	}
+	return &SignResult{
+		Leaf:              pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		Root:              pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caCert.Raw}),
+		DroppedExtensions: s.dropped,
+	}, nil
+}
+
+func TestSign(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sample-issuer-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: []string{"example.com"}}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "credentials"}}
+
+	tests := []struct {
+		name            string
+		defaultDuration *metav1.Duration
+		duration        *metav1.Duration
+		usages          []cmapi.KeyUsage
+		dropped         []string
+		signErr         error
+		policy          SignFailurePolicy
+		wantDuration    time.Duration
+		wantKeyUsage    x509.KeyUsage
+		wantExtKeyUsage []x509.ExtKeyUsage
+		wantEvent       string
+		wantPermanent   bool
+		wantIssuer      bool
+	}{
+		{name: "no default duration", wantDuration: cmapi.DefaultCertificateDuration},
+		{name: "default duration", defaultDuration: &metav1.Duration{Duration: 2 * time.Hour}, wantDuration: 2 * time.Hour},
+		{name: "requested duration", defaultDuration: &metav1.Duration{Duration: 2 * time.Hour}, duration: &metav1.Duration{Duration: 3 * time.Hour}, wantDuration: 3 * time.Hour},
+		{
+			name:            "requested usages",
+			usages:          []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageClientAuth, cmapi.UsageServerAuth},
+			wantDuration:    cmapi.DefaultCertificateDuration,
+			wantKeyUsage:    x509.KeyUsageDigitalSignature,
+			wantExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		},
+		{
+			name:         "dropped extensions",
+			dropped:      []string{"1.3.6.1.5.5.7.1.24", "1.2.3.4"},
+			wantDuration: cmapi.DefaultCertificateDuration,
+			wantEvent:    "Warning ExtensionsDropped Extensions not allowed by the issuer policy were dropped: 1.3.6.1.5.5.7.1.24, 1.2.3.4",
+		},
+		{name: "forbidden", signErr: &StatusError{StatusCode: http.StatusForbidden}, wantIssuer: true},
+		{name: "bad request", signErr: &StatusError{StatusCode: http.StatusBadRequest}, wantPermanent: true},
+		{name: "internal server error", signErr: &StatusError{StatusCode: http.StatusInternalServerError}},
+		{name: "no response", signErr: errors.New("connection refused")},
+		{name: "never policy, forbidden", signErr: &StatusError{StatusCode: http.StatusForbidden}, policy: SignFailurePolicyNever},
+		{name: "unavailable policy, no response", signErr: errors.New("connection refused"), policy: SignFailurePolicyUnavailable, wantIssuer: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &templateSigner{caCert: caCert, caKey: caKey, dropped: tc.dropped, err: tc.signErr}
+			recorder := events.NewFakeRecorder(1)
+			o := &Issuer{
+				// issuer-lib only calls Sign for Ready issuers, so Sign must
+				// not check the signing service again.
+				HealthCheckerBuilder: func(*sampleissuerapi.IssuerSpec, map[string][]byte) (HealthChecker, error) {
+					t.Error("Sign built a HealthChecker")
+					return nil, errors.New("unexpected health check")
+				},
+				SignerBuilder: func(*sampleissuerapi.IssuerSpec, map[string][]byte) (Signer, error) {
+					return s, nil
+				},
+				SignFailurePolicy: SignFailurePolicyCredentials,
+			}
+			if tc.policy != "" {
+				o.SignFailurePolicy = tc.policy
+			}
+			o.client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			o.recorder = recorder
+
+			issuer := &sampleissuerapi.SampleIssuer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "issuer", UID: "uid"},
+				Spec: sampleissuerapi.IssuerSpec{
+					URL:             "https://sample-issuer.example.com",
+					AuthSecretName:  secret.Name,
+					DefaultDuration: tc.defaultDuration,
+				},
+			}
+			cr := &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "request", CreationTimestamp: metav1.Now()},
+				Spec:       cmapi.CertificateRequestSpec{Request: csrPEM, Duration: tc.duration, Usages: tc.usages},
+			}
+
+			bundle, err := o.Sign(context.Background(), signer.CertificateRequestObjectFromCertificateRequest(cr), issuer)
+			if tc.signErr != nil {
+				if !errors.Is(err, errSignerSign) {
+					t.Fatalf("expected error to wrap errSignerSign, got %v", err)
+				}
+				if got := errors.As(err, &signer.PermanentError{}); got != tc.wantPermanent {
+					t.Errorf("PermanentError: want %v, got %v", tc.wantPermanent, got)
+				}
+				if got := errors.As(err, &signer.IssuerError{}); got != tc.wantIssuer {
+					t.Errorf("IssuerError: want %v, got %v", tc.wantIssuer, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(bundle.ChainPEM) == 0 {
+				t.Error("expected a signed certificate")
+			}
+			if got := s.template.NotAfter.Sub(s.template.NotBefore); got != tc.wantDuration {
+				t.Errorf("expected duration %v, got %v", tc.wantDuration, got)
+			}
+			if tc.usages != nil {
+				if s.template.KeyUsage != tc.wantKeyUsage {
+					t.Errorf("expected key usage %v, got %v", tc.wantKeyUsage, s.template.KeyUsage)
+				}
+				if !slices.Equal(s.template.ExtKeyUsage, tc.wantExtKeyUsage) {
+					t.Errorf("expected extended key usages %v, got %v", tc.wantExtKeyUsage, s.template.ExtKeyUsage)
+				}
+			}
+
+			var event string
+			select {
+			case event = <-recorder.Events:
+			default:
+			}
+			if event != tc.wantEvent {
+				t.Errorf("expected event %q, got %q", tc.wantEvent, event)
+			}
+		})
+	}
+}
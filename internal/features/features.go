@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package features defines the feature gates of the controller manager.
+package features
+
+import (
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/component-base/featuregate"
+)
+
+// Feature gates are declared here as constants and added to
+// defaultFeatureGates, for example:
+//
+//	// MyFeature enables my feature.
+//	MyFeature featuregate.Feature = "MyFeature"
+
+// defaultFeatureGates are the feature gates of the controller manager, with
+// their default values and maturity.
+var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{}
+
+// DefaultMutableFeatureGate is the feature gate of the controller manager.
+// It is set from the featureGates of the configuration file and the
+// --feature-gates flag.
+var DefaultMutableFeatureGate featuregate.MutableFeatureGate = featuregate.NewFeatureGate()
+
+// DefaultFeatureGate is a read-only view of DefaultMutableFeatureGate.
+var DefaultFeatureGate featuregate.FeatureGate = DefaultMutableFeatureGate
+
+func init() {
+	utilruntime.Must(DefaultMutableFeatureGate.Add(defaultFeatureGates))
+}
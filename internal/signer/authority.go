@@ -21,9 +21,26 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"fmt"
+	"math/big"
+	"sync"
 	"time"
 )
 
+const (
+	// serialNumberBits is the size of the random serial numbers assigned to
+	// certificates.
+	serialNumberBits = 128
+	// recentSerialsSize is the number of serial numbers that are remembered
+	// to detect collisions.
+	recentSerialsSize = 10000
+)
+
+// recentSerials holds the serial numbers of the certificates most recently
+// signed by this process. It is not kept in the CertificateAuthority, because
+// a CertificateAuthority is built again when its issuer or Secret changes,
+// and several issuers may use the same CA.
+var recentSerials = newSerialCache(recentSerialsSize)
+
 // CertificateAuthority implements a certificate authority that supports policy
 // based signing. It's used by the signing controller.
 type CertificateAuthority struct {
@@ -36,6 +53,22 @@ type CertificateAuthority struct {
 	PrivateKey  crypto.Signer
 	Backdate    time.Duration
 	Now         func() time.Time
+
+	// Chain is an optional chain of intermediate CA certificates which chain
+	// Certificate to Root, starting with the issuer of Certificate.
+	Chain []*x509.Certificate
+	// Root is the optional self-signed root CA certificate. It is Certificate
+	// itself if Certificate is self-signed.
+	Root *x509.Certificate
+}
+
+// IssuerChain returns the intermediate CA certificates which chain a
+// certificate signed by the CA to Root, starting with Certificate.
+func (ca *CertificateAuthority) IssuerChain() []*x509.Certificate {
+	if ca.Root == ca.Certificate {
+		return ca.Chain
+	}
+	return append([]*x509.Certificate{ca.Certificate}, ca.Chain...)
 }
 
 // Sign signs a certificate request, applying a SigningPolicy and returns a DER
@@ -55,13 +88,44 @@ func (ca *CertificateAuthority) Sign(certTemplate *x509.Certificate, policy Sign
 		return nil, err
 	}
 
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	if err := recentSerials.add(serial); err != nil {
+		return nil, err
+	}
+	certTemplate.SerialNumber = serial
+
+	certTemplate.SubjectKeyId, err = keyIdentifier(certTemplate.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the subject key identifier: %v", err)
+	}
+	// x509.CreateCertificate uses the subject key identifier of the CA
+	// certificate if it has one.
+	certTemplate.AuthorityKeyId = ca.Certificate.SubjectKeyId
+	if len(certTemplate.AuthorityKeyId) == 0 {
+		certTemplate.AuthorityKeyId, err = keyIdentifier(ca.Certificate.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute the authority key identifier: %v", err)
+		}
+	}
+
 	if !certTemplate.NotAfter.Before(ca.Certificate.NotAfter) {
 		certTemplate.NotAfter = ca.Certificate.NotAfter
 	}
+	// A CA which expired less than Backdate ago passes the first check, but
+	// the certificate would expire in the past.
 	if !now.Before(ca.Certificate.NotAfter) {
 		return nil, fmt.Errorf("refusing to sign a certificate that expired in the past")
 	}
 
+	sigAlg, err := signatureAlgorithmForKey(ca.PrivateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	certTemplate.SignatureAlgorithm = sigAlg
+
 	der, err := x509.CreateCertificate(rand.Reader, certTemplate, ca.Certificate, certTemplate.PublicKey, ca.PrivateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign certificate: %v", err)
@@ -69,3 +133,52 @@ func (ca *CertificateAuthority) Sign(certTemplate *x509.Certificate, policy Sign
 
 	return der, nil
 }
+
+// newSerialNumber returns a random positive serial number.
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate a serial number: %v", err)
+	}
+	if serial.Sign() == 0 {
+		// Serial numbers must be positive.
+		serial.SetInt64(1)
+	}
+	return serial, nil
+}
+
+// serialCache remembers a bounded number of serial numbers, evicting the
+// oldest first.
+type serialCache struct {
+	mu    sync.Mutex
+	size  int
+	seen  map[string]struct{}
+	order []string
+}
+
+func newSerialCache(size int) *serialCache {
+	return &serialCache{
+		size: size,
+		seen: make(map[string]struct{}, size),
+	}
+}
+
+// add records a serial number. It returns an error if the serial number has
+// already been recorded and not evicted since.
+func (c *serialCache) add(serial *big.Int) error {
+	key := string(serial.Bytes())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.seen[key]; ok {
+		return fmt.Errorf("serial number %x collides with a recently signed certificate", serial)
+	}
+	if len(c.order) >= c.size {
+		delete(c.seen, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.seen[key] = struct{}{}
+	c.order = append(c.order, key)
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"bytes"
+	"crypto/x509"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignSerialNumberAndKeyIdentifiers(t *testing.T) {
+	key := mustGenerateKey(t, "p256")
+	caCert := mustSelfSignedCA(t, key)
+	ca := &CertificateAuthority{
+		Certificate: caCert,
+		PrivateKey:  key,
+	}
+
+	serials := map[string]bool{}
+	for range 2 {
+		tmpl := testCertificateTemplate(t)
+		der, err := ca.Sign(tmpl, PermissiveSigningPolicy{TTL: time.Hour})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if cert.SerialNumber.Sign() <= 0 || cert.SerialNumber.BitLen() > serialNumberBits {
+			t.Errorf("invalid serial number %x", cert.SerialNumber)
+		}
+		if serials[cert.SerialNumber.String()] {
+			t.Errorf("serial number %x was assigned twice", cert.SerialNumber)
+		}
+		serials[cert.SerialNumber.String()] = true
+
+		wantSKI, err := keyIdentifier(tmpl.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(cert.SubjectKeyId, wantSKI) {
+			t.Errorf("expected subject key identifier %x, got %x", wantSKI, cert.SubjectKeyId)
+		}
+		if !bytes.Equal(cert.AuthorityKeyId, caCert.SubjectKeyId) {
+			t.Errorf("expected authority key identifier %x, got %x", caCert.SubjectKeyId, cert.AuthorityKeyId)
+		}
+	}
+}
+
+func TestSignExpiredCA(t *testing.T) {
+	key := mustGenerateKey(t, "p256")
+	caCert := mustSelfSignedCA(t, key)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantErr string
+	}{
+		{name: "valid", now: caCert.NotAfter.Add(-time.Minute)},
+		{name: "expired within the backdate", now: caCert.NotAfter.Add(time.Minute), wantErr: "refusing to sign a certificate that expired in the past"},
+		{name: "expired", now: caCert.NotAfter.Add(time.Hour), wantErr: "the signer has expired"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ca := &CertificateAuthority{
+				Certificate: caCert,
+				PrivateKey:  key,
+				Backdate:    5 * time.Minute,
+				Now:         func() time.Time { return tc.now },
+			}
+			_, err := ca.Sign(testCertificateTemplate(t), PermissiveSigningPolicy{TTL: time.Hour})
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Errorf("expected error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSerialCache(t *testing.T) {
+	cache := newSerialCache(2)
+
+	for _, serial := range []int64{1, 2} {
+		if err := cache.add(big.NewInt(serial)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cache.add(big.NewInt(1)); err == nil {
+		t.Error("expected an error for a duplicate serial number")
+	}
+
+	// Adding a third serial number evicts the oldest.
+	if err := cache.add(big.NewInt(3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.add(big.NewInt(1)); err != nil {
+		t.Errorf("unexpected error for an evicted serial number: %v", err)
+	}
+}
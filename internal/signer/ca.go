@@ -0,0 +1,187 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/controllers"
+)
+
+const (
+	// CACertificateKey is the key in the auth Secret that holds the PEM
+	// encoded CA certificate used to sign certificates, optionally followed
+	// by its chain.
+	CACertificateKey = corev1.TLSCertKey
+	// CAPrivateKeyKey is the key in the auth Secret that holds the PEM
+	// encoded private key of the CA certificate.
+	CAPrivateKeyKey = corev1.TLSPrivateKeyKey
+	// CAChainKey is the key in the auth Secret that holds an optional PEM
+	// encoded chain of intermediate certificates for the CA certificate.
+	CAChainKey = "ca.crt"
+)
+
+// caExpiringSoon is how long before the CA certificate expires the health
+// check starts warning about it.
+var caExpiringSoon = time.Hour * 24 * 30
+
+// CAHealthCheckerFromIssuerAndSecretData returns a HealthChecker which checks
+// that the CA stored in the auth Secret is valid.
+func CAHealthCheckerFromIssuerAndSecretData(issuerSpec *sampleissuerapi.IssuerSpec, secretData map[string][]byte) (controllers.HealthChecker, error) {
+	return caSignerFromIssuerAndSecretData(issuerSpec, secretData)
+}
+
+// CASignerFromIssuerAndSecretData returns a Signer which signs certificates
+// with the CA stored in the auth Secret, applying the policy of the issuer.
+func CASignerFromIssuerAndSecretData(issuerSpec *sampleissuerapi.IssuerSpec, secretData map[string][]byte) (controllers.Signer, error) {
+	return caSignerFromIssuerAndSecretData(issuerSpec, secretData)
+}
+
+type caSigner struct {
+	ca     *CertificateAuthority
+	policy SigningPolicy
+	// caPolicy is used for requests for CA certificates. If it is nil, those
+	// requests are denied.
+	caPolicy *CASigningPolicy
+}
+
+func caSignerFromIssuerAndSecretData(issuerSpec *sampleissuerapi.IssuerSpec, secretData map[string][]byte) (*caSigner, error) {
+	policy, err := newConstrainedSigningPolicy(issuerSpec, PermissiveSigningPolicy{})
+	if err != nil {
+		return nil, err
+	}
+	caPolicy, err := newCASigningPolicy(issuerSpec, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, ok := secretData[CACertificateKey]
+	if !ok {
+		return nil, fmt.Errorf("secret does not contain key %q", CACertificateKey)
+	}
+	keyPEM, ok := secretData[CAPrivateKeyKey]
+	if !ok {
+		return nil, fmt.Errorf("secret does not contain key %q", CAPrivateKeyKey)
+	}
+
+	// Secrets of CA Certificates issued by cert-manager store the chain of
+	// the CA certificate after it in tls.crt, and its root in ca.crt.
+	certs, err := parseCertChain(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %v", CACertificateKey, err)
+	}
+	cert := certs[0]
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return nil, fmt.Errorf("certificate in %q is not a CA certificate", CACertificateKey)
+	}
+
+	// The passphrase is only used to decrypt the key in memory. It must never
+	// be included in errors or logged.
+	key, err := parseKey(keyPEM, secretData[controllers.PassphraseKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", CAPrivateKeyKey, err)
+	}
+	if err := keyMatchesCert(key, cert); err != nil {
+		return nil, err
+	}
+
+	chain := certs[1:]
+	if chainPEM, ok := secretData[CAChainKey]; ok {
+		caChain, err := parseCertChain(chainPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %v", CAChainKey, err)
+		}
+		chain = appendMissingCerts(chain, caChain...)
+	}
+	chain, root := splitRoot(cert, chain)
+
+	return &caSigner{
+		ca: &CertificateAuthority{
+			RawCert:     certPEM,
+			RawKey:      keyPEM,
+			Certificate: cert,
+			PrivateKey:  key,
+			Backdate:    5 * time.Minute,
+			Chain:       chain,
+			Root:        root,
+		},
+		policy:   policy,
+		caPolicy: caPolicy,
+	}, nil
+}
+
+func (o *caSigner) Check(context.Context) (*controllers.HealthCheckResult, error) {
+	result := &controllers.HealthCheckResult{CA: o.ca.Certificate}
+	return result, checkCAExpiry(o.ca.Certificate, time.Now())
+}
+
+func (o *caSigner) Sign(_ context.Context, certTemplate *x509.Certificate) (*controllers.SignResult, error) {
+	policy := o.policy
+	if certTemplate.IsCA {
+		if o.caPolicy == nil {
+			return nil, &controllers.PolicyError{Err: errCAIssuanceNotAllowed}
+		}
+		caPolicy, err := o.caPolicy.limitPathLen(o.ca.Certificate)
+		if err != nil {
+			return nil, err
+		}
+		policy = caPolicy
+	}
+
+	// The policy replaces the requested extensions with the allowed ones.
+	requested := certTemplate.Extensions
+	crtDER, err := o.ca.Sign(certTemplate, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &controllers.SignResult{
+		Leaf:              pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: crtDER}),
+		Chain:             encodeCerts(o.ca.IssuerChain()...),
+		DroppedExtensions: droppedExtensions(requested, certTemplate.ExtraExtensions),
+	}
+	if o.ca.Root != nil {
+		result.Root = encodeCerts(o.ca.Root)
+	}
+	return result, nil
+}
+
+// checkCAExpiry returns a HealthCheckError if the CA certificate has expired
+// or is about to expire.
+func checkCAExpiry(cert *x509.Certificate, now time.Time) error {
+	if !now.Before(cert.NotAfter) {
+		return &controllers.HealthCheckError{
+			Reason: controllers.HealthCheckReasonCAExpired,
+			Err:    fmt.Errorf("the CA certificate has expired: NotAfter=%v", cert.NotAfter),
+		}
+	}
+	if !now.Add(caExpiringSoon).Before(cert.NotAfter) {
+		return &controllers.HealthCheckError{
+			Reason:  controllers.HealthCheckReasonCAExpiringSoon,
+			Err:     fmt.Errorf("the CA certificate expires soon: NotAfter=%v", cert.NotAfter),
+			Warning: true,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/controllers"
+)
+
+func TestCheckCAExpiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		notAfter    time.Time
+		wantReason  string
+		wantWarning bool
+	}{
+		{name: "valid", notAfter: now.Add(365 * 24 * time.Hour)},
+		{name: "expiring soon", notAfter: now.Add(24 * time.Hour), wantReason: controllers.HealthCheckReasonCAExpiringSoon, wantWarning: true},
+		{name: "expired", notAfter: now.Add(-time.Second), wantReason: controllers.HealthCheckReasonCAExpired},
+		{name: "expires now", notAfter: now, wantReason: controllers.HealthCheckReasonCAExpired},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkCAExpiry(&x509.Certificate{NotAfter: tc.notAfter}, now)
+			if tc.wantReason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var healthErr *controllers.HealthCheckError
+			if !errors.As(err, &healthErr) {
+				t.Fatalf("expected a HealthCheckError, got %v", err)
+			}
+			if healthErr.Reason != tc.wantReason {
+				t.Errorf("expected reason %q, got %q", tc.wantReason, healthErr.Reason)
+			}
+			if healthErr.Warning != tc.wantWarning {
+				t.Errorf("expected warning %v, got %v", tc.wantWarning, healthErr.Warning)
+			}
+		})
+	}
+}
+
+func TestCASignerCheck(t *testing.T) {
+	key := mustGenerateKey(t, "p256")
+	caCert := mustSelfSignedCA(t, key)
+	checker, err := CAHealthCheckerFromIssuerAndSecretData(&sampleissuerapi.IssuerSpec{}, map[string][]byte{
+		CACertificateKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw}),
+		CAPrivateKeyKey:  mustEncodeKey(t, key, "PRIVATE KEY"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := checker.Check(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.CA == nil || !result.CA.Equal(caCert) {
+		t.Errorf("expected the CA certificate of the issuer, got %v", result.CA)
+	}
+}
+
+func TestCASignerCAIssuance(t *testing.T) {
+	key := mustGenerateKey(t, "p256")
+	caCert := mustSelfSignedCA(t, key)
+	secretData := map[string][]byte{
+		CACertificateKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw}),
+		CAPrivateKeyKey:  mustEncodeKey(t, key, "PRIVATE KEY"),
+	}
+
+	caTemplate := func() *x509.Certificate {
+		tmpl := testCertificateTemplate(t)
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		return tmpl
+	}
+
+	signer, err := CASignerFromIssuerAndSecretData(&sampleissuerapi.IssuerSpec{}, secretData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var policyErr *controllers.PolicyError
+	if _, err := signer.Sign(context.Background(), caTemplate()); !errors.As(err, &policyErr) {
+		t.Fatalf("expected a PolicyError without caIssuance, got %v", err)
+	}
+
+	maxPathLen := int32(1)
+	signer, err = CASignerFromIssuerAndSecretData(&sampleissuerapi.IssuerSpec{
+		CAIssuance: &sampleissuerapi.CAIssuanceSpec{
+			MaxPathLen: &maxPathLen,
+			NameConstraints: &sampleissuerapi.NameConstraintsSpec{
+				PermittedDNSDomains: []string{"example.com"},
+				ExcludedIPRanges:    []string{"10.0.0.0/8"},
+			},
+		},
+	}, secretData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := signer.Sign(context.Background(), caTemplate())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := parseCert(signed.Leaf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cert.IsCA || cert.MaxPathLen != 1 {
+		t.Errorf("expected a CA certificate with path length 1, got IsCA=%v MaxPathLen=%d", cert.IsCA, cert.MaxPathLen)
+	}
+	if cert.KeyUsage != x509.KeyUsageCertSign|x509.KeyUsageCRLSign || len(cert.ExtKeyUsage) != 0 {
+		t.Errorf("unexpected key usages %v %v", cert.KeyUsage, cert.ExtKeyUsage)
+	}
+	if !cert.PermittedDNSDomainsCritical || len(cert.PermittedDNSDomains) != 1 || len(cert.ExcludedIPRanges) != 1 {
+		t.Errorf("unexpected name constraints: permitted %v, excluded %v", cert.PermittedDNSDomains, cert.ExcludedIPRanges)
+	}
+}
+
+func TestCASignerSignChain(t *testing.T) {
+	rootKey := mustGenerateKey(t, "p256")
+	rootCert := mustSelfSignedCA(t, rootKey)
+	intermediateKey := mustGenerateKey(t, "p256")
+	intermediateCert := mustIntermediateCA(t, "test-intermediate-ca", intermediateKey, rootCert, rootKey)
+	subordinateKey := mustGenerateKey(t, "p256")
+	subordinateCert := mustIntermediateCA(t, "test-subordinate-ca", subordinateKey, intermediateCert, intermediateKey)
+
+	tests := []struct {
+		name       string
+		secretData map[string][]byte
+		wantChain  []*x509.Certificate
+	}{
+		{
+			name: "self-signed CA",
+			secretData: map[string][]byte{
+				CACertificateKey: encodeCerts(rootCert),
+				CAPrivateKeyKey:  mustEncodeKey(t, rootKey, "PRIVATE KEY"),
+			},
+		},
+		{
+			name: "intermediate CA",
+			secretData: map[string][]byte{
+				CACertificateKey: encodeCerts(intermediateCert),
+				CAPrivateKeyKey:  mustEncodeKey(t, intermediateKey, "PRIVATE KEY"),
+				CAChainKey:       encodeCerts(rootCert),
+			},
+			wantChain: []*x509.Certificate{intermediateCert},
+		},
+		{
+			name: "intermediate CA with the chain in ca.crt",
+			secretData: map[string][]byte{
+				CACertificateKey: encodeCerts(subordinateCert),
+				CAPrivateKeyKey:  mustEncodeKey(t, subordinateKey, "PRIVATE KEY"),
+				CAChainKey:       encodeCerts(intermediateCert, rootCert),
+			},
+			wantChain: []*x509.Certificate{subordinateCert, intermediateCert},
+		},
+		{
+			// cert-manager stores the chain after the CA certificate in
+			// tls.crt, and the root in ca.crt.
+			name: "intermediate CA issued by cert-manager",
+			secretData: map[string][]byte{
+				CACertificateKey: encodeCerts(subordinateCert, intermediateCert),
+				CAPrivateKeyKey:  mustEncodeKey(t, subordinateKey, "PRIVATE KEY"),
+				CAChainKey:       encodeCerts(rootCert),
+			},
+			wantChain: []*x509.Certificate{subordinateCert, intermediateCert},
+		},
+		{
+			name: "intermediate CA with the root in tls.crt and ca.crt",
+			secretData: map[string][]byte{
+				CACertificateKey: encodeCerts(subordinateCert, intermediateCert, rootCert),
+				CAPrivateKeyKey:  mustEncodeKey(t, subordinateKey, "PRIVATE KEY"),
+				CAChainKey:       encodeCerts(rootCert),
+			},
+			wantChain: []*x509.Certificate{subordinateCert, intermediateCert},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := CASignerFromIssuerAndSecretData(&sampleissuerapi.IssuerSpec{}, tc.secretData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signed, err := signer.Sign(context.Background(), testCertificateTemplate(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			leaf, err := parseCert(signed.Leaf)
+			if err != nil {
+				t.Fatalf("invalid leaf: %v", err)
+			}
+			if want := encodeCerts(tc.wantChain...); string(signed.Chain) != string(want) {
+				t.Errorf("expected chain %q, got %q", want, signed.Chain)
+			}
+			if want := encodeCerts(rootCert); string(signed.Root) != string(want) {
+				t.Errorf("expected root %q, got %q", want, signed.Root)
+			}
+
+			roots := x509.NewCertPool()
+			roots.AppendCertsFromPEM(signed.Root)
+			intermediates := x509.NewCertPool()
+			intermediates.AppendCertsFromPEM(signed.Chain)
+			if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates}); err != nil {
+				t.Errorf("expected the leaf to verify to the root: %v", err)
+			}
+		})
+	}
+}
+
+// TestCASignerSignClientAuth checks that an issuer without allowedUsages signs
+// the client certificates of mTLS.
+func TestCASignerSignClientAuth(t *testing.T) {
+	caKey := mustGenerateKey(t, "p256")
+	caCert := mustSelfSignedCA(t, caKey)
+	signer, err := CASignerFromIssuerAndSecretData(&sampleissuerapi.IssuerSpec{}, map[string][]byte{
+		CACertificateKey: encodeCerts(caCert),
+		CAPrivateKeyKey:  mustEncodeKey(t, caKey, "PRIVATE KEY"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := testCertificateTemplate(t)
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	signed, err := signer.Sign(context.Background(), tmpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leaf, err := parseCert(signed.Leaf)
+	if err != nil {
+		t.Fatalf("invalid leaf: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err != nil {
+		t.Errorf("expected a client certificate: %v", err)
+	}
+}
+
+func mustIntermediateCA(t *testing.T, commonName string, key crypto.Signer, parentCert *x509.Certificate, parentKey crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	template := testCertificateTemplate(t)
+	template.Subject = pkix.Name{CommonName: commonName}
+	template.DNSNames = nil
+	template.PublicKey = key.Public()
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	der, err := (&CertificateAuthority{Certificate: parentCert, PrivateKey: parentKey}).Sign(template, CASigningPolicy{MaxPathLen: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
@@ -0,0 +1,514 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/controllers"
+	"github.com/cert-manager/sample-external-issuer/internal/x509policy"
+)
+
+const (
+	// TokenKey is the key in the auth Secret that holds the bearer token used
+	// to authenticate to the signing service.
+	TokenKey = "token"
+
+	// signPath is the path, relative to the issuer URL, of the endpoint which
+	// signs certificates.
+	signPath = "sign"
+	// healthPath is the path, relative to the issuer URL, of the endpoint which
+	// reports the health of the signing service.
+	healthPath = "healthz"
+
+	contentTypeJSON     = "application/json"
+	contentTypePEMChain = "application/pem-certificate-chain"
+
+	// maxResponseSize limits how much of a response body is read.
+	maxResponseSize = 1 << 20
+)
+
+// HTTPHealthCheckerFromIssuerAndSecretData returns a HealthChecker for the
+// signing service at the issuer URL.
+func HTTPHealthCheckerFromIssuerAndSecretData(issuerSpec *sampleissuerapi.IssuerSpec, secretData map[string][]byte) (controllers.HealthChecker, error) {
+	return httpSignerFromIssuerAndSecretData(issuerSpec, secretData)
+}
+
+// HTTPSignerFromIssuerAndSecretData returns a Signer which sends certificate
+// templates to the signing service at the issuer URL.
+func HTTPSignerFromIssuerAndSecretData(issuerSpec *sampleissuerapi.IssuerSpec, secretData map[string][]byte) (controllers.Signer, error) {
+	return httpSignerFromIssuerAndSecretData(issuerSpec, secretData)
+}
+
+type httpSigner struct {
+	baseURL *url.URL
+	token   string
+	client  *http.Client
+	// policy is only used to validate the names and duration of a request,
+	// and to select the extensions which are forwarded, before it is sent to
+	// the signing service, which is responsible for the rest.
+	policy ConstrainedSigningPolicy
+	// caPolicy is applied to requests for CA certificates before they are
+	// sent to the signing service, and the certificates it returns are
+	// checked against it. If it is nil, those requests are denied.
+	caPolicy *CASigningPolicy
+}
+
+func httpSignerFromIssuerAndSecretData(issuerSpec *sampleissuerapi.IssuerSpec, secretData map[string][]byte) (*httpSigner, error) {
+	baseURL, err := url.Parse(issuerSpec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %v", issuerSpec.URL, err)
+	}
+	if (baseURL.Scheme != "https" && baseURL.Scheme != "http") || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: must be an absolute http or https URL", issuerSpec.URL)
+	}
+
+	token, ok := secretData[TokenKey]
+	if !ok || len(token) == 0 {
+		return nil, fmt.Errorf("secret does not contain key %q", TokenKey)
+	}
+
+	policy, err := newConstrainedSigningPolicy(issuerSpec, PermissiveSigningPolicy{})
+	if err != nil {
+		return nil, err
+	}
+	caPolicy, err := newCASigningPolicy(issuerSpec, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newHTTPClient(issuerSpec.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpSigner{
+		baseURL:  baseURL,
+		token:    strings.TrimSpace(string(token)),
+		client:   client,
+		policy:   policy,
+		caPolicy: caPolicy,
+	}, nil
+}
+
+// newHTTPClient returns the client used to call the signing service. The
+// certificate of the signing service is verified with the CA bundle of the
+// issuer if it has one, and with the system roots otherwise.
+func newHTTPClient(tlsSpec *sampleissuerapi.TLSSpec) (*http.Client, error) {
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	if tlsSpec == nil || len(tlsSpec.CABundle) == 0 {
+		return client, nil
+	}
+
+	certs, err := parseCertChain(tlsSpec.CABundle)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tls.caBundle: %v", err)
+	}
+	rootCAs := x509.NewCertPool()
+	for _, cert := range certs {
+		rootCAs.AddCert(cert)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	client.Transport = transport
+	return client, nil
+}
+
+// httpHealthResponse is the body of a successful JSON response from the
+// health endpoint.
+type httpHealthResponse struct {
+	// CA is the PEM encoded CA certificate used by the signing service.
+	CA string `json:"ca,omitempty"`
+	// Version is the version of the signing service.
+	Version string `json:"version,omitempty"`
+}
+
+// Check probes the health endpoint of the signing service with the
+// credentials from the auth Secret, and checks the expiry of the CA
+// certificate if the signing service reports one. The CA certificate and the
+// version reported by the signing service are returned in the result.
+func (o *httpSigner) Check(ctx context.Context) (*controllers.HealthCheckResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL.JoinPath(healthPath).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", contentTypeJSON)
+
+	resp, err := o.do(req)
+	if err != nil {
+		var statusErr *controllers.StatusError
+		if errors.As(err, &statusErr) &&
+			(statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden) {
+			return nil, &controllers.HealthCheckError{Reason: controllers.HealthCheckReasonUnauthorized, Err: err}
+		}
+		return nil, &controllers.HealthCheckError{Reason: controllers.HealthCheckReasonUnreachable, Err: err}
+	}
+
+	result := &controllers.HealthCheckResult{}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != contentTypeJSON {
+		return result, nil
+	}
+
+	var health httpHealthResponse
+	if err := json.Unmarshal(resp.Body, &health); err != nil {
+		return nil, &controllers.HealthCheckError{
+			Reason: controllers.HealthCheckReasonInvalidResponse,
+			Err:    fmt.Errorf("failed to decode health response: %v", err),
+		}
+	}
+	result.BackendVersion = health.Version
+	if health.CA == "" {
+		return result, nil
+	}
+
+	caCert, err := parseCert([]byte(health.CA))
+	if err != nil {
+		return nil, &controllers.HealthCheckError{
+			Reason: controllers.HealthCheckReasonInvalidResponse,
+			Err:    fmt.Errorf("failed to parse CA certificate reported by the signing service: %v", err),
+		}
+	}
+	result.CA = caCert
+
+	return result, checkCAExpiry(caCert, time.Now())
+}
+
+// httpSignRequest is the body of a request to the sign endpoint.
+type httpSignRequest struct {
+	// PublicKey is the PEM encoded PKIX public key of the requester.
+	PublicKey string `json:"publicKey"`
+	// Subject is the DER encoded X.509 subject.
+	Subject        []byte                `json:"subject,omitempty"`
+	DNSNames       []string              `json:"dnsNames,omitempty"`
+	IPAddresses    []string              `json:"ipAddresses,omitempty"`
+	URIs           []string              `json:"uris,omitempty"`
+	EmailAddresses []string              `json:"emailAddresses,omitempty"`
+	NotBefore      time.Time             `json:"notBefore"`
+	NotAfter       time.Time             `json:"notAfter"`
+	IsCA           bool                  `json:"isCA,omitempty"`
+	Usages         []x509policy.KeyUsage `json:"usages,omitempty"`
+	Extensions     []httpExtension       `json:"extensions,omitempty"`
+	// MaxPathLen is the maximum path length of a CA certificate. It is not
+	// set if the path length is not limited.
+	MaxPathLen *int `json:"maxPathLen,omitempty"`
+	// NameConstraints are the name constraints of a CA certificate.
+	NameConstraints *httpNameConstraints `json:"nameConstraints,omitempty"`
+}
+
+// httpNameConstraints are the name constraints which the signing service
+// must add to a CA certificate.
+type httpNameConstraints struct {
+	PermittedDNSDomains []string `json:"permittedDNSDomains,omitempty"`
+	ExcludedDNSDomains  []string `json:"excludedDNSDomains,omitempty"`
+	// PermittedIPRanges and ExcludedIPRanges are CIDR ranges.
+	PermittedIPRanges []string `json:"permittedIPRanges,omitempty"`
+	ExcludedIPRanges  []string `json:"excludedIPRanges,omitempty"`
+}
+
+// httpExtension is an extension of the CSR which is allowed by the issuer
+// policy.
+type httpExtension struct {
+	// OID is the dotted OID of the extension.
+	OID      string `json:"oid"`
+	Critical bool   `json:"critical,omitempty"`
+	// Value is the DER encoded value of the extension.
+	Value []byte `json:"value"`
+}
+
+// httpSignResponse is the body of a successful JSON response from the sign
+// endpoint. All fields are PEM encoded.
+type httpSignResponse struct {
+	Certificate string `json:"certificate"`
+	Chain       string `json:"chain,omitempty"`
+	CA          string `json:"ca,omitempty"`
+}
+
+// httpErrorResponse is the body of an unsuccessful JSON response.
+type httpErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func (o *httpSigner) Sign(ctx context.Context, certTemplate *x509.Certificate) (*controllers.SignResult, error) {
+	requested := certTemplate.Extensions
+	if certTemplate.IsCA {
+		if o.caPolicy == nil {
+			return nil, &controllers.PolicyError{Err: errCAIssuanceNotAllowed}
+		}
+		// The CA policy sets the usages, path length and name constraints
+		// which are sent to the signing service, and replaces the requested
+		// extensions with the allowed ones.
+		if err := o.caPolicy.apply(certTemplate); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := o.policy.constrain(certTemplate); err != nil {
+			return nil, err
+		}
+		certTemplate.ExtraExtensions = o.policy.allowedExtensions(certTemplate.Extensions)
+	}
+
+	body, err := newHTTPSignRequest(certTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.baseURL.JoinPath(signPath).String(), bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+	req.Header.Set("Accept", contentTypeJSON+", "+contentTypePEMChain)
+
+	resp, err := o.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid response content type: %v", err)
+	}
+
+	var certPEM []byte
+	switch mediaType {
+	case contentTypeJSON:
+		var signed httpSignResponse
+		if err := json.Unmarshal(resp.Body, &signed); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %v", err)
+		}
+		certPEM = []byte(signed.Certificate + signed.Chain + signed.CA)
+	case contentTypePEMChain, "application/x-pem-file":
+		certPEM = resp.Body
+	default:
+		return nil, fmt.Errorf("unsupported response content type %q", mediaType)
+	}
+
+	certs, err := parseCertChain(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("response does not contain PEM encoded certificates: %v", err)
+	}
+	if err := checkSignedCA(certTemplate, certs[0]); err != nil {
+		return nil, fmt.Errorf("invalid certificate returned by the signing service: %v", err)
+	}
+
+	// The certificates after the leaf are its issuers, which may end with the
+	// root.
+	result := &controllers.SignResult{
+		Leaf:              encodeCerts(certs[0]),
+		DroppedExtensions: droppedExtensions(requested, certTemplate.ExtraExtensions),
+	}
+	issuers := certs[1:]
+	if n := len(issuers); n > 0 && isSelfSigned(issuers[n-1]) {
+		result.Root = encodeCerts(issuers[n-1])
+		issuers = issuers[:n-1]
+	}
+	result.Chain = encodeCerts(issuers...)
+
+	return result, nil
+}
+
+type httpResponse struct {
+	Header http.Header
+	Body   []byte
+}
+
+// do sends the request to the signing service and reads the response. A
+// non-2xx response is returned as a controllers.StatusError.
+func (o *httpSigner) do(req *http.Request) (*httpResponse, error) {
+	req.Header.Set("Authorization", "Bearer "+o.token)
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &controllers.StatusError{
+			StatusCode: resp.StatusCode,
+			Message:    errorMessage(resp.Header, body),
+		}
+	}
+
+	return &httpResponse{Header: resp.Header, Body: body}, nil
+}
+
+func newHTTPSignRequest(certTemplate *x509.Certificate) (*httpSignRequest, error) {
+	publicKey, err := x509.MarshalPKIXPublicKey(certTemplate.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %v", err)
+	}
+
+	subject := certTemplate.RawSubject
+	if subject == nil {
+		subject, err = asn1.Marshal(certTemplate.Subject.ToRDNSequence())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal subject: %v", err)
+		}
+	}
+
+	req := &httpSignRequest{
+		PublicKey:      string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+		Subject:        subject,
+		DNSNames:       certTemplate.DNSNames,
+		EmailAddresses: certTemplate.EmailAddresses,
+		NotBefore:      certTemplate.NotBefore,
+		NotAfter:       certTemplate.NotAfter,
+		IsCA:           certTemplate.IsCA,
+		Usages:         x509policy.KeyUsagesToStrings(certTemplate.KeyUsage, certTemplate.ExtKeyUsage),
+	}
+	for _, ip := range certTemplate.IPAddresses {
+		req.IPAddresses = append(req.IPAddresses, ip.String())
+	}
+	for _, uri := range certTemplate.URIs {
+		req.URIs = append(req.URIs, uri.String())
+	}
+	for _, ext := range certTemplate.ExtraExtensions {
+		req.Extensions = append(req.Extensions, httpExtension{
+			OID:      ext.Id.String(),
+			Critical: ext.Critical,
+			Value:    ext.Value,
+		})
+	}
+
+	if certTemplate.IsCA {
+		if certTemplate.MaxPathLen >= 0 {
+			req.MaxPathLen = &certTemplate.MaxPathLen
+		}
+		nc := httpNameConstraints{
+			PermittedDNSDomains: certTemplate.PermittedDNSDomains,
+			ExcludedDNSDomains:  certTemplate.ExcludedDNSDomains,
+			PermittedIPRanges:   ipNetStrings(certTemplate.PermittedIPRanges),
+			ExcludedIPRanges:    ipNetStrings(certTemplate.ExcludedIPRanges),
+		}
+		if !reflect.DeepEqual(nc, httpNameConstraints{}) {
+			req.NameConstraints = &nc
+		}
+	}
+
+	return req, nil
+}
+
+// checkSignedCA checks that the signing service returned a CA certificate if
+// and only if one was requested, and that a CA certificate has at least the
+// path length and name constraints of the template.
+func checkSignedCA(tmpl, cert *x509.Certificate) error {
+	if !tmpl.IsCA {
+		if cert.IsCA {
+			return errors.New("it is a CA certificate, but no CA certificate was requested")
+		}
+		return nil
+	}
+	if !cert.IsCA {
+		return errors.New("it is not a CA certificate")
+	}
+	if tmpl.MaxPathLen >= 0 && (cert.MaxPathLen < 0 || cert.MaxPathLen > tmpl.MaxPathLen) {
+		return fmt.Errorf("its path length is not limited to %d", tmpl.MaxPathLen)
+	}
+
+	for _, names := range []struct {
+		kind                     string
+		permitted, certPermitted []string
+		excluded, certExcluded   []string
+	}{
+		{"DNS domains", tmpl.PermittedDNSDomains, cert.PermittedDNSDomains, tmpl.ExcludedDNSDomains, cert.ExcludedDNSDomains},
+		{"IP ranges", ipNetStrings(tmpl.PermittedIPRanges), ipNetStrings(cert.PermittedIPRanges),
+			ipNetStrings(tmpl.ExcludedIPRanges), ipNetStrings(cert.ExcludedIPRanges)},
+	} {
+		// The certificate may permit fewer names and exclude more names than
+		// the template, but not the other way around.
+		if len(names.permitted) > 0 && (len(names.certPermitted) == 0 || !isSubset(names.certPermitted, names.permitted)) {
+			return fmt.Errorf("it permits %s %q, which must be within %q", names.kind, names.certPermitted, names.permitted)
+		}
+		if !isSubset(names.excluded, names.certExcluded) {
+			return fmt.Errorf("it excludes %s %q, which must include %q", names.kind, names.certExcluded, names.excluded)
+		}
+	}
+	if tmpl.PermittedDNSDomainsCritical && !cert.PermittedDNSDomainsCritical {
+		return errors.New("its name constraints are not critical")
+	}
+	return nil
+}
+
+// isSubset reports whether every element of a is in b.
+func isSubset(a, b []string) bool {
+	for _, s := range a {
+		if !slices.Contains(b, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// ipNetStrings returns the CIDR notation of IP ranges.
+func ipNetStrings(ipNets []*net.IPNet) []string {
+	var out []string
+	for _, ipNet := range ipNets {
+		out = append(out, ipNet.String())
+	}
+	return out
+}
+
+// errorMessage extracts a human readable message from an error response.
+func errorMessage(header http.Header, body []byte) string {
+	if mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type")); mediaType == contentTypeJSON {
+		var errResp httpErrorResponse
+		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
+			return errResp.Message
+		}
+	}
+
+	message := strings.TrimSpace(string(body))
+	if len(message) > 256 {
+		message = message[:256] + "..."
+	}
+	return message
+}
@@ -0,0 +1,491 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/controllers"
+)
+
+const (
+	testToken          = "test-token"
+	testBackendVersion = "v1.2.3"
+)
+
+// fakeSigningService is an httptest based stand-in for the signing service,
+// which signs certificates with a self-signed CA.
+type fakeSigningService struct {
+	*httptest.Server
+
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+
+	// responseContentType selects the format of successful responses.
+	responseContentType string
+	// statusCode, if set, is returned instead of signing the request.
+	statusCode int
+	// healthBody, if set, is returned by the health endpoint instead of the
+	// CA certificate and version.
+	healthBody string
+	// ignoreCAPolicy signs CA certificates without the requested path length
+	// and name constraints.
+	ignoreCAPolicy bool
+}
+
+func newFakeSigningService(t *testing.T) *fakeSigningService {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fake-signing-service"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeSigningService{
+		caCert:              caCert,
+		caKey:               caKey,
+		responseContentType: contentTypeJSON,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/sign", s.sign)
+	mux.HandleFunc("GET /api/healthz", s.healthz)
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *fakeSigningService) sign(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		writeJSON(w, http.StatusUnauthorized, httpErrorResponse{Message: "invalid token"})
+		return
+	}
+	if s.statusCode != 0 {
+		writeJSON(w, s.statusCode, httpErrorResponse{Message: http.StatusText(s.statusCode)})
+		return
+	}
+
+	var req httpSignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, httpErrorResponse{Message: err.Error()})
+		return
+	}
+	block, _ := pem.Decode([]byte(req.PublicKey))
+	if block == nil {
+		writeJSON(w, http.StatusBadRequest, httpErrorResponse{Message: "invalid public key"})
+		return
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, httpErrorResponse{Message: err.Error()})
+		return
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		RawSubject:   req.Subject,
+		DNSNames:     req.DNSNames,
+		NotBefore:    req.NotBefore,
+		NotAfter:     req.NotAfter,
+	}
+	if req.IsCA {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		tmpl.MaxPathLen = -1
+	}
+	if req.MaxPathLen != nil && !s.ignoreCAPolicy {
+		tmpl.MaxPathLen = *req.MaxPathLen
+		tmpl.MaxPathLenZero = *req.MaxPathLen == 0
+	}
+	if nc := req.NameConstraints; nc != nil && !s.ignoreCAPolicy {
+		tmpl.PermittedDNSDomainsCritical = true
+		tmpl.PermittedDNSDomains = nc.PermittedDNSDomains
+		tmpl.ExcludedDNSDomains = nc.ExcludedDNSDomains
+		for _, cidr := range nc.ExcludedIPRanges {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, httpErrorResponse{Message: err.Error()})
+				return
+			}
+			tmpl.ExcludedIPRanges = append(tmpl.ExcludedIPRanges, ipNet)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, s.caCert, publicKey, s.caKey)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, httpErrorResponse{Message: err.Error()})
+		return
+	}
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caCert.Raw}))
+	if s.responseContentType == contentTypePEMChain {
+		w.Header().Set("Content-Type", contentTypePEMChain)
+		_, _ = w.Write([]byte(certPEM + caPEM))
+		return
+	}
+	writeJSON(w, http.StatusOK, httpSignResponse{Certificate: certPEM, CA: caPEM})
+}
+
+func (s *fakeSigningService) healthz(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+testToken {
+		writeJSON(w, http.StatusUnauthorized, httpErrorResponse{Message: "invalid token"})
+		return
+	}
+	if s.statusCode != 0 {
+		writeJSON(w, s.statusCode, httpErrorResponse{Message: http.StatusText(s.statusCode)})
+		return
+	}
+	if s.healthBody != "" {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		_, _ = w.Write([]byte(s.healthBody))
+		return
+	}
+	writeJSON(w, http.StatusOK, httpHealthResponse{
+		CA:      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caCert.Raw})),
+		Version: testBackendVersion,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func testCertificateTemplate(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "example.com"},
+		DNSNames:    []string{"example.com"},
+		PublicKey:   key.Public(),
+		NotBefore:   time.Now(),
+		NotAfter:    time.Now().Add(time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+}
+
+func TestHTTPSignerSign(t *testing.T) {
+	for _, contentType := range []string{contentTypeJSON, contentTypePEMChain} {
+		t.Run(contentType, func(t *testing.T) {
+			service := newFakeSigningService(t)
+			service.responseContentType = contentType
+
+			signer, err := HTTPSignerFromIssuerAndSecretData(
+				&sampleissuerapi.IssuerSpec{URL: service.URL + "/api"},
+				map[string][]byte{TokenKey: []byte(testToken)},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signed, err := signer.Sign(context.Background(), testCertificateTemplate(t))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cert, err := parseCert(signed.Leaf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cert.Subject.CommonName; got != "example.com" {
+				t.Errorf("unexpected common name %q", got)
+			}
+			if err := cert.CheckSignatureFrom(service.caCert); err != nil {
+				t.Errorf("certificate is not signed by the signing service CA: %v", err)
+			}
+			if len(signed.Chain) != 0 {
+				t.Errorf("expected no intermediates, got %q", signed.Chain)
+			}
+			if root, err := parseCert(signed.Root); err != nil || !root.Equal(service.caCert) {
+				t.Errorf("expected the signing service CA as root, got %q", signed.Root)
+			}
+		})
+	}
+}
+
+func TestHTTPSignerSignCA(t *testing.T) {
+	maxPathLen := int32(0)
+	caIssuance := &sampleissuerapi.CAIssuanceSpec{
+		MaxPathLen: &maxPathLen,
+		NameConstraints: &sampleissuerapi.NameConstraintsSpec{
+			PermittedDNSDomains: []string{"example.com"},
+			ExcludedIPRanges:    []string{"0.0.0.0/0"},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		caIssuance     *sampleissuerapi.CAIssuanceSpec
+		ignoreCAPolicy bool
+		wantPolicyErr  bool
+		wantErr        bool
+	}{
+		{name: "CA issuance not allowed", wantPolicyErr: true},
+		{name: "CA issuance allowed", caIssuance: caIssuance},
+		{name: "signing service ignores the CA policy", caIssuance: caIssuance, ignoreCAPolicy: true, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newFakeSigningService(t)
+			service.ignoreCAPolicy = tc.ignoreCAPolicy
+
+			signer, err := HTTPSignerFromIssuerAndSecretData(
+				&sampleissuerapi.IssuerSpec{URL: service.URL + "/api", CAIssuance: tc.caIssuance},
+				map[string][]byte{TokenKey: []byte(testToken)},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			certTemplate := testCertificateTemplate(t)
+			certTemplate.IsCA = true
+			signed, err := signer.Sign(context.Background(), certTemplate)
+
+			var policyErr *controllers.PolicyError
+			if errors.As(err, &policyErr) != tc.wantPolicyErr {
+				t.Fatalf("wantPolicyErr %v, got %v", tc.wantPolicyErr, err)
+			}
+			if (err != nil) != (tc.wantErr || tc.wantPolicyErr) {
+				t.Fatalf("wantErr %v, got %v", tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			cert, err := parseCert(signed.Leaf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cert.IsCA || cert.MaxPathLen != 0 || !cert.MaxPathLenZero {
+				t.Errorf("expected a CA certificate with a path length of 0, got IsCA=%v MaxPathLen=%d", cert.IsCA, cert.MaxPathLen)
+			}
+			if len(cert.PermittedDNSDomains) != 1 || cert.PermittedDNSDomains[0] != "example.com" || len(cert.ExcludedIPRanges) != 1 {
+				t.Errorf("expected the name constraints of the issuer, got %v and %v", cert.PermittedDNSDomains, cert.ExcludedIPRanges)
+			}
+		})
+	}
+}
+
+func TestHTTPSignerSignStatusError(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		statusCode int
+	}{
+		{name: "unauthorized", token: "wrong-token", statusCode: http.StatusUnauthorized},
+		{name: "bad request", token: testToken, statusCode: http.StatusBadRequest},
+		{name: "unprocessable entity", token: testToken, statusCode: http.StatusUnprocessableEntity},
+		{name: "service unavailable", token: testToken, statusCode: http.StatusServiceUnavailable},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newFakeSigningService(t)
+			if tc.token == testToken {
+				service.statusCode = tc.statusCode
+			}
+
+			signer, err := HTTPSignerFromIssuerAndSecretData(
+				&sampleissuerapi.IssuerSpec{URL: service.URL + "/api"},
+				map[string][]byte{TokenKey: []byte(tc.token)},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = signer.Sign(context.Background(), testCertificateTemplate(t))
+			var statusErr *controllers.StatusError
+			if !errors.As(err, &statusErr) {
+				t.Fatalf("expected a StatusError, got %v", err)
+			}
+			if statusErr.StatusCode != tc.statusCode {
+				t.Errorf("expected status code %d, got %d", tc.statusCode, statusErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestHTTPSignerFromIssuerAndSecretData(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		secretData map[string][]byte
+		wantErr    bool
+	}{
+		{name: "valid", url: "https://signer.example.com/api", secretData: map[string][]byte{TokenKey: []byte(testToken)}},
+		{name: "relative URL", url: "/api", secretData: map[string][]byte{TokenKey: []byte(testToken)}, wantErr: true},
+		{name: "unsupported scheme", url: "ftp://signer.example.com", secretData: map[string][]byte{TokenKey: []byte(testToken)}, wantErr: true},
+		{name: "missing token", url: "https://signer.example.com/api", secretData: map[string][]byte{}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := HTTPSignerFromIssuerAndSecretData(&sampleissuerapi.IssuerSpec{URL: tc.url}, tc.secretData)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("wantErr %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestHTTPSignerCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, httpHealthResponse{Version: testBackendVersion})
+	}))
+	t.Cleanup(server.Close)
+	serverCAPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	otherCAPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: newFakeSigningService(t).caCert.Raw})
+
+	tests := []struct {
+		name       string
+		tls        *sampleissuerapi.TLSSpec
+		wantErr    bool
+		wantReason string
+	}{
+		{name: "trusted CA bundle", tls: &sampleissuerapi.TLSSpec{CABundle: serverCAPEM}},
+		{name: "system roots", wantReason: controllers.HealthCheckReasonUnreachable},
+		{name: "other CA bundle", tls: &sampleissuerapi.TLSSpec{CABundle: otherCAPEM}, wantReason: controllers.HealthCheckReasonUnreachable},
+		{name: "invalid CA bundle", tls: &sampleissuerapi.TLSSpec{CABundle: []byte("not a certificate")}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			checker, err := HTTPHealthCheckerFromIssuerAndSecretData(
+				&sampleissuerapi.IssuerSpec{URL: server.URL + "/api", TLS: tc.tls},
+				map[string][]byte{TokenKey: []byte(testToken)},
+			)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("wantErr %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			result, err := checker.Check(context.Background())
+			if tc.wantReason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if result.BackendVersion != testBackendVersion {
+					t.Errorf("expected backend version %q, got %q", testBackendVersion, result.BackendVersion)
+				}
+				return
+			}
+
+			var healthErr *controllers.HealthCheckError
+			if !errors.As(err, &healthErr) || healthErr.Reason != tc.wantReason {
+				t.Errorf("expected a HealthCheckError with reason %q, got %v", tc.wantReason, err)
+			}
+		})
+	}
+}
+
+func TestHTTPSignerCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		statusCode int
+		healthBody string
+		stopped    bool
+		wantReason string
+	}{
+		{name: "healthy", token: testToken},
+		{name: "invalid response", token: testToken, healthBody: "not json", wantReason: controllers.HealthCheckReasonInvalidResponse},
+		{name: "invalid CA", token: testToken, healthBody: `{"ca":"not a certificate"}`, wantReason: controllers.HealthCheckReasonInvalidResponse},
+		{name: "unauthorized", token: "wrong-token", wantReason: controllers.HealthCheckReasonUnauthorized},
+		{name: "service unavailable", token: testToken, statusCode: http.StatusServiceUnavailable, wantReason: controllers.HealthCheckReasonUnreachable},
+		{name: "unreachable", token: testToken, stopped: true, wantReason: controllers.HealthCheckReasonUnreachable},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newFakeSigningService(t)
+			service.statusCode = tc.statusCode
+			service.healthBody = tc.healthBody
+			if tc.stopped {
+				service.Close()
+			}
+
+			checker, err := HTTPHealthCheckerFromIssuerAndSecretData(
+				&sampleissuerapi.IssuerSpec{URL: service.URL + "/api"},
+				map[string][]byte{TokenKey: []byte(tc.token)},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := checker.Check(context.Background())
+			if tc.wantReason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if result.CA == nil || !result.CA.Equal(service.caCert) {
+					t.Errorf("expected the CA certificate of the signing service, got %v", result.CA)
+				}
+				if result.BackendVersion != testBackendVersion {
+					t.Errorf("expected backend version %q, got %q", testBackendVersion, result.BackendVersion)
+				}
+				return
+			}
+
+			var healthErr *controllers.HealthCheckError
+			if !errors.As(err, &healthErr) {
+				t.Fatalf("expected a HealthCheckError, got %v", err)
+			}
+			if healthErr.Reason != tc.wantReason {
+				t.Errorf("expected reason %q, got %q", tc.wantReason, healthErr.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+// maxPBKDF2Iterations limits the work done to derive the key of an encrypted
+// private key. Keys are decrypted whenever the Secret of an issuer changes, so
+// a huge iteration count in the key would block the controller.
+const maxPBKDF2Iterations = 10_000_000
+
+// errIncorrectPassphrase is returned when an encrypted private key cannot be
+// decrypted, which almost always means the passphrase is wrong.
+var errIncorrectPassphrase = errors.New("failed to decrypt private key: incorrect passphrase")
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is the ASN.1 structure of an "ENCRYPTED PRIVATE
+// KEY" PEM block, see RFC 5958 section 3.
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params is defined in RFC 8018 appendix A.4.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params is defined in RFC 8018 appendix A.2. Only the specified
+// variant of the salt is supported.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8 decrypts the DER encoded EncryptedPrivateKeyInfo of a PKCS#8
+// private key which was encrypted with PBES2, using PBKDF2 and AES-CBC, and
+// returns the DER encoded PKCS#8 PrivateKeyInfo.
+func decryptPKCS8(der []byte, passphrase []byte) ([]byte, error) {
+	var info encryptedPrivateKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("invalid encrypted private key: %v", err)
+	} else if len(rest) != 0 {
+		return nil, errors.New("invalid encrypted private key: trailing data")
+	}
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("unsupported encryption algorithm %s, only PBES2 is supported", info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("invalid PBES2 parameters: %v", err)
+	}
+
+	keyLength, newBlock, iv, err := pbes2Cipher(params.EncryptionScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := pbes2Key(params.KeyDerivationFunc, passphrase, keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := newBlock(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.EncryptedData) == 0 || len(info.EncryptedData)%block.BlockSize() != 0 {
+		return nil, errors.New("invalid encrypted private key: data is not a multiple of the block size")
+	}
+
+	decrypted := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(decrypted, info.EncryptedData)
+
+	return unpad(decrypted, block.BlockSize())
+}
+
+// pbes2Cipher returns the key length, block cipher and IV of a PBES2
+// encryption scheme.
+func pbes2Cipher(scheme pkix.AlgorithmIdentifier) (int, func([]byte) (cipher.Block, error), []byte, error) {
+	var keyLength int
+	switch {
+	case scheme.Algorithm.Equal(oidAES128CBC):
+		keyLength = 16
+	case scheme.Algorithm.Equal(oidAES192CBC):
+		keyLength = 24
+	case scheme.Algorithm.Equal(oidAES256CBC):
+		keyLength = 32
+	default:
+		return 0, nil, nil, fmt.Errorf("unsupported encryption scheme %s, must be one of AES-128-CBC, AES-192-CBC or AES-256-CBC", scheme.Algorithm)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(scheme.Parameters.FullBytes, &iv); err != nil {
+		return 0, nil, nil, fmt.Errorf("invalid encryption scheme parameters: %v", err)
+	}
+	if len(iv) != aes.BlockSize {
+		return 0, nil, nil, fmt.Errorf("invalid IV length %d", len(iv))
+	}
+
+	return keyLength, aes.NewCipher, iv, nil
+}
+
+// pbes2Key derives the encryption key from the passphrase with the PBES2 key
+// derivation function, which must be PBKDF2.
+func pbes2Key(kdf pkix.AlgorithmIdentifier, passphrase []byte, keyLength int) ([]byte, error) {
+	if !kdf.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("unsupported key derivation function %s, only PBKDF2 is supported", kdf.Algorithm)
+	}
+
+	var params pbkdf2Params
+	if _, err := asn1.Unmarshal(kdf.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("invalid PBKDF2 parameters: %v", err)
+	}
+	if params.IterationCount <= 0 || params.IterationCount > maxPBKDF2Iterations {
+		return nil, fmt.Errorf("invalid PBKDF2 iteration count %d, must be between 1 and %d", params.IterationCount, maxPBKDF2Iterations)
+	}
+	if params.KeyLength != 0 && params.KeyLength != keyLength {
+		return nil, fmt.Errorf("PBKDF2 key length %d does not match the encryption scheme", params.KeyLength)
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(params.PRF.Algorithm) == 0, params.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case params.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case params.PRF.Algorithm.Equal(oidHMACWithSHA384):
+		prf = sha512.New384
+	case params.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("unsupported PBKDF2 PRF %s", params.PRF.Algorithm)
+	}
+
+	return pbkdf2.Key(prf, string(passphrase), params.Salt, params.IterationCount, keyLength)
+}
+
+// unpad removes the PKCS#7 padding of the decrypted data. Invalid padding is
+// reported as an incorrect passphrase.
+func unpad(data []byte, blockSize int) ([]byte, error) {
+	n := int(data[len(data)-1])
+	if n == 0 || n > blockSize || n > len(data) {
+		return nil, errIncorrectPassphrase
+	}
+	if !bytes.Equal(data[len(data)-n:], bytes.Repeat([]byte{byte(n)}, n)) {
+		return nil, errIncorrectPassphrase
+	}
+	return data[:len(data)-n], nil
+}
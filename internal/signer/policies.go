@@ -18,11 +18,18 @@ package signer
 
 import (
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
 	"fmt"
-	"sort"
+	"net"
+	"slices"
+	"strings"
 	"time"
 
-	capi "k8s.io/api/certificates/v1beta1"
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/controllers"
+	"github.com/cert-manager/sample-external-issuer/internal/x509policy"
 )
 
 // SigningPolicy validates a CertificateRequest before it's signed by the
@@ -38,29 +45,40 @@ type SigningPolicy interface {
 // signer.
 //
 //   - It forwards all SANs from the original signing request.
-//   - It sets allowed usages as configured in the policy.
-//   - It sets NotAfter based on the TTL configured in the policy.
-//   - It zeros all extensions.
+//   - It sets the usages configured in the policy, or keeps the requested
+//     usages if none are configured.
+//   - It sets NotAfter based on the TTL configured in the policy, or keeps the
+//     requested NotAfter if the TTL is zero.
+//   - It copies the requested extensions in AllowedExtensions, and zeros all
+//     other extensions.
 //   - It sets BasicConstraints to true.
 //   - It sets IsCA to false.
 type PermissiveSigningPolicy struct {
 	// TTL is the certificate TTL. It's used to calculate the NotAfter value of
-	// the certificate.
+	// the certificate, unless it is zero.
 	TTL time.Duration
-	// Usages are the allowed usages of a certificate.
-	Usages []capi.KeyUsage
+	// Usages are the usages of a certificate. If nil, the requested usages
+	// are kept.
+	Usages []x509policy.KeyUsage
+	// AllowedExtensions are the OIDs of the extensions which are copied from
+	// the Extensions of the template, which are the extensions of the CSR.
+	AllowedExtensions []x509.OID
 }
 
 func (p PermissiveSigningPolicy) apply(tmpl *x509.Certificate) error {
-	usage, extUsages, err := keyUsagesFromStrings(p.Usages)
-	if err != nil {
-		return err
+	if p.Usages != nil {
+		usage, extUsages, err := x509policy.KeyUsagesFromStrings(p.Usages)
+		if err != nil {
+			return err
+		}
+		tmpl.KeyUsage = usage
+		tmpl.ExtKeyUsage = extUsages
+	}
+	if p.TTL != 0 {
+		tmpl.NotAfter = tmpl.NotBefore.Add(p.TTL)
 	}
-	tmpl.KeyUsage = usage
-	tmpl.ExtKeyUsage = extUsages
-	tmpl.NotAfter = tmpl.NotBefore.Add(p.TTL)
 
-	tmpl.ExtraExtensions = nil
+	tmpl.ExtraExtensions = p.allowedExtensions(tmpl.Extensions)
 	tmpl.Extensions = nil
 	tmpl.BasicConstraintsValid = true
 	tmpl.IsCA = false
@@ -68,75 +86,447 @@ func (p PermissiveSigningPolicy) apply(tmpl *x509.Certificate) error {
 	return nil
 }
 
-var keyUsageDict = map[capi.KeyUsage]x509.KeyUsage{
-	capi.UsageSigning:           x509.KeyUsageDigitalSignature,
-	capi.UsageDigitalSignature:  x509.KeyUsageDigitalSignature,
-	capi.UsageContentCommitment: x509.KeyUsageContentCommitment,
-	capi.UsageKeyEncipherment:   x509.KeyUsageKeyEncipherment,
-	capi.UsageKeyAgreement:      x509.KeyUsageKeyAgreement,
-	capi.UsageDataEncipherment:  x509.KeyUsageDataEncipherment,
-	capi.UsageCertSign:          x509.KeyUsageCertSign,
-	capi.UsageCRLSign:           x509.KeyUsageCRLSign,
-	capi.UsageEncipherOnly:      x509.KeyUsageEncipherOnly,
-	capi.UsageDecipherOnly:      x509.KeyUsageDecipherOnly,
+// allowedExtensions returns the requested extensions which are allowed by the
+// policy.
+func (p PermissiveSigningPolicy) allowedExtensions(requested []pkix.Extension) []pkix.Extension {
+	var allowed []pkix.Extension
+	for _, ext := range requested {
+		if slices.ContainsFunc(p.AllowedExtensions, func(oid x509.OID) bool { return oid.EqualASN1OID(ext.Id) }) {
+			allowed = append(allowed, ext)
+		}
+	}
+	return allowed
+}
+
+// templateExtensions are the extensions of a CSR which are parsed into the
+// fields of the certificate template, so they are not dropped.
+var templateExtensions = []asn1.ObjectIdentifier{
+	{2, 5, 29, 15}, // key usage
+	{2, 5, 29, 17}, // subject alternative name
+	{2, 5, 29, 19}, // basic constraints
+	{2, 5, 29, 37}, // extended key usage
+}
+
+// parseAllowedExtensions parses the OIDs of the allowed extensions of an
+// issuer policy.
+func parseAllowedExtensions(oids []string) ([]x509.OID, error) {
+	var allowed []x509.OID
+	for _, s := range oids {
+		oid, err := x509policy.ParseExtensionOID(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy.allowedExtensions entry %q: %v", s, err)
+		}
+		allowed = append(allowed, oid)
+	}
+	return allowed, nil
+}
+
+// droppedExtensions returns the OIDs of the requested extensions which are
+// not in the signed extensions, ignoring the extensions which are parsed into
+// the fields of the certificate template.
+func droppedExtensions(requested, signed []pkix.Extension) []string {
+	var dropped []string
+	for _, ext := range requested {
+		if slices.ContainsFunc(templateExtensions, ext.Id.Equal) ||
+			slices.ContainsFunc(signed, func(s pkix.Extension) bool { return s.Id.Equal(ext.Id) }) {
+			continue
+		}
+		dropped = append(dropped, ext.Id.String())
+	}
+	return dropped
+}
+
+// defaultAllowedUsages are the usages which may be requested from an issuer
+// which does not configure allowed usages: those of TLS server and client
+// certificates.
+var defaultAllowedUsages = []x509policy.KeyUsage{
+	x509policy.UsageDigitalSignature,
+	x509policy.UsageKeyEncipherment,
+	x509policy.UsageServerAuth,
+	x509policy.UsageClientAuth,
+}
+
+// ConstrainedSigningPolicy is a PermissiveSigningPolicy which only allows the
+// subject alternative names, usages and durations configured in the policy. A
+// request containing any other name or usage is denied with a
+// controllers.PolicyError. A list which is nil allows anything of that type.
+type ConstrainedSigningPolicy struct {
+	PermissiveSigningPolicy
+
+	// AllowedUsages are the usages which may be requested.
+	AllowedUsages []x509policy.KeyUsage
+
+	// MinDuration and MaxDuration bound the duration of the certificate, if
+	// they are not zero.
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	// RejectDuration denies requests for a duration outside of the bounds,
+	// instead of clamping the duration to the nearest bound.
+	RejectDuration bool
+
+	// AllowedDNSNames are DNS name patterns, see matchesDNSPattern.
+	AllowedDNSNames []string
+	// AllowedIPRanges are the networks that IP addresses must be part of.
+	AllowedIPRanges []*net.IPNet
+	// AllowedURISchemes are the allowed schemes of URIs.
+	AllowedURISchemes []string
+	// AllowedURIHosts are DNS name patterns for the hosts of URIs.
+	AllowedURIHosts []string
+	// AllowedEmailDomains are DNS name patterns for the domains of email
+	// addresses.
+	AllowedEmailDomains []string
+}
+
+// newConstrainedSigningPolicy returns a ConstrainedSigningPolicy for an
+// issuer. If the issuer has no policy, all names are allowed.
+func newConstrainedSigningPolicy(issuerSpec *sampleissuerapi.IssuerSpec, base PermissiveSigningPolicy) (ConstrainedSigningPolicy, error) {
+	p := ConstrainedSigningPolicy{
+		PermissiveSigningPolicy: base,
+		AllowedUsages:           defaultAllowedUsages,
+		RejectDuration:          issuerSpec.DurationPolicy == sampleissuerapi.DurationPolicyReject,
+	}
+	if issuerSpec.AllowedUsages != nil {
+		p.AllowedUsages = x509policy.KeyUsagesFrom(issuerSpec.AllowedUsages)
+		if _, _, err := x509policy.KeyUsagesFromStrings(p.AllowedUsages); err != nil {
+			return p, fmt.Errorf("invalid allowedUsages: %v", err)
+		}
+	}
+	if issuerSpec.MinDuration != nil {
+		p.MinDuration = issuerSpec.MinDuration.Duration
+	}
+	if issuerSpec.MaxDuration != nil {
+		p.MaxDuration = issuerSpec.MaxDuration.Duration
+	}
+	if p.MinDuration < 0 || p.MaxDuration < 0 {
+		return p, errors.New("minDuration and maxDuration must not be negative")
+	}
+	if p.MaxDuration != 0 && p.MinDuration > p.MaxDuration {
+		return p, fmt.Errorf("minDuration %v is greater than maxDuration %v", p.MinDuration, p.MaxDuration)
+	}
+
+	spec := issuerSpec.Policy
+	if spec == nil {
+		return p, nil
+	}
+
+	for _, field := range []struct {
+		name     string
+		patterns []string
+	}{
+		{"allowedDNSNames", spec.AllowedDNSNames},
+		{"allowedURIHosts", spec.AllowedURIHosts},
+		{"allowedEmailDomains", spec.AllowedEmailDomains},
+	} {
+		for _, pattern := range field.patterns {
+			if err := x509policy.ValidateDNSPattern(pattern); err != nil {
+				return p, fmt.Errorf("invalid policy.%s pattern %q: %v", field.name, pattern, err)
+			}
+		}
+	}
+
+	for _, cidr := range spec.AllowedIPRanges {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return p, fmt.Errorf("invalid policy.allowedIPRanges entry %q: %v", cidr, err)
+		}
+		p.AllowedIPRanges = append(p.AllowedIPRanges, ipNet)
+	}
+	if spec.AllowedIPRanges != nil && p.AllowedIPRanges == nil {
+		p.AllowedIPRanges = []*net.IPNet{}
+	}
+
+	allowedExtensions, err := parseAllowedExtensions(spec.AllowedExtensions)
+	if err != nil {
+		return p, err
+	}
+	p.AllowedExtensions = allowedExtensions
+
+	p.AllowedDNSNames = spec.AllowedDNSNames
+	p.AllowedURISchemes = spec.AllowedURISchemes
+	p.AllowedURIHosts = spec.AllowedURIHosts
+	p.AllowedEmailDomains = spec.AllowedEmailDomains
+
+	return p, nil
+}
+
+func (p ConstrainedSigningPolicy) apply(tmpl *x509.Certificate) error {
+	if err := p.PermissiveSigningPolicy.apply(tmpl); err != nil {
+		return err
+	}
+	return p.constrain(tmpl)
+}
+
+// constrain validates the names and usages of the template and clamps or
+// rejects its duration.
+func (p ConstrainedSigningPolicy) constrain(tmpl *x509.Certificate) error {
+	if err := p.validateNames(tmpl); err != nil {
+		return err
+	}
+	if err := p.validateUsages(tmpl); err != nil {
+		return err
+	}
+	return p.constrainDuration(tmpl)
+}
+
+// validateUsages returns a controllers.PolicyError listing every usage of the
+// template which is not allowed by the policy.
+func (p ConstrainedSigningPolicy) validateUsages(tmpl *x509.Certificate) error {
+	if p.AllowedUsages == nil {
+		return nil
+	}
+	allowedUsage, allowedExtUsages, err := x509policy.KeyUsagesFromStrings(p.AllowedUsages)
+	if err != nil {
+		return err
+	}
+
+	deniedUsage := tmpl.KeyUsage &^ allowedUsage
+	var deniedExtUsages []x509.ExtKeyUsage
+	for _, extUsage := range tmpl.ExtKeyUsage {
+		if !slices.Contains(allowedExtUsages, extUsage) {
+			deniedExtUsages = append(deniedExtUsages, extUsage)
+		}
+	}
+
+	if deniedUsage != 0 || len(deniedExtUsages) > 0 {
+		return &controllers.PolicyError{
+			Err: fmt.Errorf("usages not allowed: %q", x509policy.KeyUsagesToStrings(deniedUsage, deniedExtUsages)),
+		}
+	}
+	return nil
+}
+
+// constrainDuration changes NotAfter so that the duration of the template is
+// within the bounds of the policy, or returns a controllers.PolicyError if
+// RejectDuration is set.
+func (p ConstrainedSigningPolicy) constrainDuration(tmpl *x509.Certificate) error {
+	requested := tmpl.NotAfter.Sub(tmpl.NotBefore)
+
+	bound := requested
+	switch {
+	case p.MinDuration != 0 && requested < p.MinDuration:
+		bound = p.MinDuration
+	case p.MaxDuration != 0 && requested > p.MaxDuration:
+		bound = p.MaxDuration
+	default:
+		return nil
+	}
+
+	if p.RejectDuration {
+		return &controllers.PolicyError{
+			Err: fmt.Errorf("requested duration %v is not between %v and %v", requested, p.MinDuration, p.MaxDuration),
+		}
+	}
+
+	tmpl.NotAfter = tmpl.NotBefore.Add(bound)
+	return nil
+}
+
+// validateNames returns a controllers.PolicyError listing every subject
+// alternative name of the template which is not allowed by the policy.
+func (p ConstrainedSigningPolicy) validateNames(tmpl *x509.Certificate) error {
+	var denied []string
+
+	if p.AllowedDNSNames != nil {
+		for _, name := range tmpl.DNSNames {
+			if !matchesAnyDNSPattern(p.AllowedDNSNames, name) {
+				denied = append(denied, fmt.Sprintf("DNS name %q", name))
+			}
+		}
+	}
+
+	if p.AllowedIPRanges != nil {
+		for _, ip := range tmpl.IPAddresses {
+			if !slices.ContainsFunc(p.AllowedIPRanges, func(ipNet *net.IPNet) bool { return ipNet.Contains(ip) }) {
+				denied = append(denied, fmt.Sprintf("IP address %q", ip))
+			}
+		}
+	}
+
+	for _, uri := range tmpl.URIs {
+		if p.AllowedURISchemes != nil && !slices.ContainsFunc(p.AllowedURISchemes, func(scheme string) bool {
+			return strings.EqualFold(scheme, uri.Scheme)
+		}) {
+			denied = append(denied, fmt.Sprintf("URI %q", uri))
+			continue
+		}
+		if p.AllowedURIHosts != nil && !matchesAnyDNSPattern(p.AllowedURIHosts, uri.Hostname()) {
+			denied = append(denied, fmt.Sprintf("URI %q", uri))
+		}
+	}
+
+	if p.AllowedEmailDomains != nil {
+		for _, email := range tmpl.EmailAddresses {
+			_, domain, ok := strings.Cut(email, "@")
+			if !ok || !matchesAnyDNSPattern(p.AllowedEmailDomains, domain) {
+				denied = append(denied, fmt.Sprintf("email address %q", email))
+			}
+		}
+	}
+
+	if len(denied) > 0 {
+		return &controllers.PolicyError{
+			Err: fmt.Errorf("subject alternative names not allowed: %s", strings.Join(denied, ", ")),
+		}
+	}
+	return nil
 }
 
-var extKeyUsageDict = map[capi.KeyUsage]x509.ExtKeyUsage{
-	capi.UsageAny:             x509.ExtKeyUsageAny,
-	capi.UsageServerAuth:      x509.ExtKeyUsageServerAuth,
-	capi.UsageClientAuth:      x509.ExtKeyUsageClientAuth,
-	capi.UsageCodeSigning:     x509.ExtKeyUsageCodeSigning,
-	capi.UsageEmailProtection: x509.ExtKeyUsageEmailProtection,
-	capi.UsageSMIME:           x509.ExtKeyUsageEmailProtection,
-	capi.UsageIPsecEndSystem:  x509.ExtKeyUsageIPSECEndSystem,
-	capi.UsageIPsecTunnel:     x509.ExtKeyUsageIPSECTunnel,
-	capi.UsageIPsecUser:       x509.ExtKeyUsageIPSECUser,
-	capi.UsageTimestamping:    x509.ExtKeyUsageTimeStamping,
-	capi.UsageOCSPSigning:     x509.ExtKeyUsageOCSPSigning,
-	capi.UsageMicrosoftSGC:    x509.ExtKeyUsageMicrosoftServerGatedCrypto,
-	capi.UsageNetscapeSGC:     x509.ExtKeyUsageNetscapeServerGatedCrypto,
+// errCAIssuanceNotAllowed is the reason requests for a CA certificate are
+// denied by an issuer which does not allow CA issuance.
+var errCAIssuanceNotAllowed = errors.New("the issuer does not allow CA certificates, see caIssuance")
+
+// CASigningPolicy is the signing policy for requests for CA certificates. It
+// applies the ConstrainedSigningPolicy, and then:
+//
+//   - It sets IsCA to true.
+//   - It limits the path length to MaxPathLen.
+//   - It replaces the requested usages with cert sign and CRL sign.
+//   - It adds the name constraints configured in the policy.
+type CASigningPolicy struct {
+	ConstrainedSigningPolicy
+
+	// MaxPathLen is the maximum path length of the certificate. A negative
+	// value allows any path length.
+	MaxPathLen int
+
+	PermittedDNSDomains []string
+	ExcludedDNSDomains  []string
+	PermittedIPRanges   []*net.IPNet
+	ExcludedIPRanges    []*net.IPNet
 }
 
-// keyUsagesFromStrings will translate a slice of usage strings from the
-// certificates API ("pkg/apis/certificates".KeyUsage) to x509.KeyUsage and
-// x509.ExtKeyUsage types.
-func keyUsagesFromStrings(usages []capi.KeyUsage) (x509.KeyUsage, []x509.ExtKeyUsage, error) {
-	var keyUsage x509.KeyUsage
-	var unrecognized []capi.KeyUsage
-	extKeyUsages := make(map[x509.ExtKeyUsage]struct{})
-	for _, usage := range usages {
-		if val, ok := keyUsageDict[usage]; ok {
-			keyUsage |= val
-		} else if val, ok := extKeyUsageDict[usage]; ok {
-			extKeyUsages[val] = struct{}{}
-		} else {
-			unrecognized = append(unrecognized, usage)
+// newCASigningPolicy returns a CASigningPolicy for an issuer, or nil if the
+// issuer does not allow CA issuance.
+func newCASigningPolicy(issuerSpec *sampleissuerapi.IssuerSpec, base ConstrainedSigningPolicy) (*CASigningPolicy, error) {
+	spec := issuerSpec.CAIssuance
+	if spec == nil {
+		return nil, nil
+	}
+
+	p := &CASigningPolicy{
+		ConstrainedSigningPolicy: base,
+		MaxPathLen:               -1,
+	}
+	if spec.MaxPathLen != nil {
+		if *spec.MaxPathLen < 0 {
+			return nil, errors.New("caIssuance.maxPathLen must not be negative")
 		}
+		p.MaxPathLen = int(*spec.MaxPathLen)
 	}
 
-	sorted := make(sortedExtKeyUsage, 0, len(extKeyUsages))
-	for eku := range extKeyUsages {
-		sorted = append(sorted, eku)
+	nc := spec.NameConstraints
+	if nc == nil {
+		return p, nil
 	}
-	sort.Sort(sorted)
+	p.PermittedDNSDomains = nc.PermittedDNSDomains
+	p.ExcludedDNSDomains = nc.ExcludedDNSDomains
 
-	if len(unrecognized) > 0 {
-		return 0, nil, fmt.Errorf("unrecognized usage values: %q", unrecognized)
+	for _, field := range []struct {
+		name   string
+		cidrs  []string
+		ranges *[]*net.IPNet
+	}{
+		{"permittedIPRanges", nc.PermittedIPRanges, &p.PermittedIPRanges},
+		{"excludedIPRanges", nc.ExcludedIPRanges, &p.ExcludedIPRanges},
+	} {
+		for _, cidr := range field.cidrs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid caIssuance.nameConstraints.%s entry %q: %v", field.name, cidr, err)
+			}
+			*field.ranges = append(*field.ranges, ipNet)
+		}
 	}
 
-	return keyUsage, sorted, nil
+	return p, nil
 }
 
-type sortedExtKeyUsage []x509.ExtKeyUsage
+// limitPathLen returns a copy of the policy with the path length limited to
+// less than the path length of the CA certificate which signs the
+// certificates. It returns a controllers.PolicyError if the CA certificate
+// cannot sign CA certificates.
+func (p CASigningPolicy) limitPathLen(caCert *x509.Certificate) (CASigningPolicy, error) {
+	if caCert.MaxPathLen < 0 || (caCert.MaxPathLen == 0 && !caCert.MaxPathLenZero) {
+		return p, nil
+	}
+	if caCert.MaxPathLen == 0 {
+		return p, &controllers.PolicyError{
+			Err: errors.New("the path length of the CA certificate of the issuer does not allow it to sign CA certificates"),
+		}
+	}
+	if p.MaxPathLen < 0 || p.MaxPathLen >= caCert.MaxPathLen {
+		p.MaxPathLen = caCert.MaxPathLen - 1
+	}
+	return p, nil
+}
+
+func (p CASigningPolicy) apply(tmpl *x509.Certificate) error {
+	if !tmpl.IsCA {
+		return errors.New("the CA signing policy only signs CA certificates")
+	}
+	// A path length of zero is only requested if MaxPathLenZero is set, see
+	// x509.Certificate. The requested path length is limited to MaxPathLen
+	// below.
+	requestedPathLen := -1
+	if tmpl.MaxPathLen > 0 || tmpl.MaxPathLenZero {
+		requestedPathLen = tmpl.MaxPathLen
+	}
+
+	// The usages of CA certificates are not requested, so they are not
+	// validated against the allowed usages.
+	tmpl.KeyUsage = 0
+	tmpl.ExtKeyUsage = nil
+	if err := p.ConstrainedSigningPolicy.apply(tmpl); err != nil {
+		return err
+	}
+
+	tmpl.IsCA = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	tmpl.ExtKeyUsage = nil
 
-func (s sortedExtKeyUsage) Len() int {
-	return len(s)
+	pathLen := requestedPathLen
+	if p.MaxPathLen >= 0 && (pathLen < 0 || pathLen > p.MaxPathLen) {
+		pathLen = p.MaxPathLen
+	}
+	tmpl.MaxPathLen = pathLen
+	tmpl.MaxPathLenZero = pathLen == 0
+
+	tmpl.PermittedDNSDomains = p.PermittedDNSDomains
+	tmpl.ExcludedDNSDomains = p.ExcludedDNSDomains
+	tmpl.PermittedIPRanges = p.PermittedIPRanges
+	tmpl.ExcludedIPRanges = p.ExcludedIPRanges
+	// RFC 5280 requires the name constraints extension to be critical.
+	tmpl.PermittedDNSDomainsCritical = len(p.PermittedDNSDomains)+len(p.ExcludedDNSDomains)+len(p.PermittedIPRanges)+len(p.ExcludedIPRanges) > 0
+
+	return nil
 }
 
-func (s sortedExtKeyUsage) Swap(i, j int) {
-	s[i], s[j] = s[j], s[i]
+func matchesAnyDNSPattern(patterns []string, name string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		return matchesDNSPattern(pattern, name)
+	})
 }
 
-func (s sortedExtKeyUsage) Less(i, j int) bool {
-	return s[i] < s[j]
+// matchesDNSPattern reports whether a DNS name matches a pattern, ignoring
+// case:
+//
+//   - "example.com" only matches "example.com".
+//   - "*.example.com" matches any single label in place of the wildcard, for
+//     example "www.example.com" and "*.example.com" but not
+//     "a.b.example.com".
+//   - ".example.com" matches any subdomain of example.com, but not
+//     "example.com" itself.
+func matchesDNSPattern(pattern, name string) bool {
+	pattern = strings.ToLower(pattern)
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+
+	switch {
+	case strings.HasPrefix(pattern, "*."):
+		label, rest, ok := strings.Cut(name, ".")
+		return ok && label != "" && rest == pattern[2:]
+	case strings.HasPrefix(pattern, "."):
+		return len(name) > len(pattern) && strings.HasSuffix(name, pattern)
+	default:
+		return name == pattern
+	}
 }
@@ -0,0 +1,315 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"net"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sampleissuerapi "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/controllers"
+)
+
+func TestMatchesDNSPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "example.com", name: "example.com", want: true},
+		{pattern: "example.com", name: "EXAMPLE.com.", want: true},
+		{pattern: "example.com", name: "www.example.com", want: false},
+		{pattern: "*.example.com", name: "www.example.com", want: true},
+		{pattern: "*.example.com", name: "*.example.com", want: true},
+		{pattern: "*.example.com", name: "a.b.example.com", want: false},
+		{pattern: "*.example.com", name: "example.com", want: false},
+		{pattern: ".example.com", name: "a.b.example.com", want: true},
+		{pattern: ".example.com", name: "example.com", want: false},
+		{pattern: ".example.com", name: "badexample.com", want: false},
+	}
+	for _, tc := range tests {
+		if got := matchesDNSPattern(tc.pattern, tc.name); got != tc.want {
+			t.Errorf("matchesDNSPattern(%q, %q): want %v, got %v", tc.pattern, tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestNewConstrainedSigningPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		spec sampleissuerapi.PolicySpec
+	}{
+		{name: "invalid CIDR", spec: sampleissuerapi.PolicySpec{AllowedIPRanges: []string{"10.0.0.0"}}},
+		{name: "wildcard in the middle", spec: sampleissuerapi.PolicySpec{AllowedDNSNames: []string{"www.*.example.com"}}},
+		{name: "partial wildcard", spec: sampleissuerapi.PolicySpec{AllowedDNSNames: []string{"*www.example.com"}}},
+		{name: "empty pattern", spec: sampleissuerapi.PolicySpec{AllowedEmailDomains: []string{""}}},
+		{name: "invalid extension OID", spec: sampleissuerapi.PolicySpec{AllowedExtensions: []string{"1.3.6.1.5.5.7.1."}}},
+		{name: "issuer extension", spec: sampleissuerapi.PolicySpec{AllowedExtensions: []string{"2.5.29.17"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newConstrainedSigningPolicy(&sampleissuerapi.IssuerSpec{Policy: &tc.spec}, PermissiveSigningPolicy{}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	t.Run("unknown usage", func(t *testing.T) {
+		spec := &sampleissuerapi.IssuerSpec{AllowedUsages: []string{"server auth", "teleport"}}
+		if _, err := newConstrainedSigningPolicy(spec, PermissiveSigningPolicy{}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("minDuration greater than maxDuration", func(t *testing.T) {
+		spec := &sampleissuerapi.IssuerSpec{
+			MinDuration: &metav1.Duration{Duration: 2 * time.Hour},
+			MaxDuration: &metav1.Duration{Duration: time.Hour},
+		}
+		if _, err := newConstrainedSigningPolicy(spec, PermissiveSigningPolicy{}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestConstrainedSigningPolicy(t *testing.T) {
+	spec := &sampleissuerapi.PolicySpec{
+		AllowedDNSNames:     []string{"example.com", "*.example.com", ".internal.example.com"},
+		AllowedIPRanges:     []string{"10.0.0.0/8", "fd00::/8"},
+		AllowedURISchemes:   []string{"spiffe"},
+		AllowedURIHosts:     []string{"cluster.local"},
+		AllowedEmailDomains: []string{"example.com"},
+	}
+	spiffeURI, _ := url.Parse("spiffe://cluster.local/ns/default/sa/default")
+	httpsURI, _ := url.Parse("https://cluster.local/")
+	otherHostURI, _ := url.Parse("spiffe://example.org/ns/default/sa/default")
+
+	tests := []struct {
+		name    string
+		spec    *sampleissuerapi.PolicySpec
+		tmpl    *x509.Certificate
+		wantErr bool
+	}{
+		{
+			name: "no policy",
+			tmpl: &x509.Certificate{DNSNames: []string{"anything.example.org"}},
+		},
+		{
+			name: "allowed names",
+			spec: spec,
+			tmpl: &x509.Certificate{
+				DNSNames:       []string{"example.com", "www.example.com", "a.b.internal.example.com"},
+				IPAddresses:    []net.IP{net.ParseIP("10.1.2.3"), net.ParseIP("fd00::1")},
+				URIs:           []*url.URL{spiffeURI},
+				EmailAddresses: []string{"admin@example.com"},
+			},
+		},
+		{name: "DNS name denied", spec: spec, tmpl: &x509.Certificate{DNSNames: []string{"a.b.example.com"}}, wantErr: true},
+		{name: "IP address denied", spec: spec, tmpl: &x509.Certificate{IPAddresses: []net.IP{net.ParseIP("192.168.0.1")}}, wantErr: true},
+		{name: "URI scheme denied", spec: spec, tmpl: &x509.Certificate{URIs: []*url.URL{httpsURI}}, wantErr: true},
+		{name: "URI host denied", spec: spec, tmpl: &x509.Certificate{URIs: []*url.URL{otherHostURI}}, wantErr: true},
+		{name: "email domain denied", spec: spec, tmpl: &x509.Certificate{EmailAddresses: []string{"admin@example.org"}}, wantErr: true},
+		{
+			name:    "empty list denies all",
+			spec:    &sampleissuerapi.PolicySpec{AllowedIPRanges: []string{}},
+			tmpl:    &x509.Certificate{IPAddresses: []net.IP{net.ParseIP("10.1.2.3")}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := newConstrainedSigningPolicy(&sampleissuerapi.IssuerSpec{Policy: tc.spec}, PermissiveSigningPolicy{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = policy.apply(tc.tmpl)
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var policyErr *controllers.PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("expected a PolicyError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConstrainedSigningPolicyDuration(t *testing.T) {
+	notBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		policy       sampleissuerapi.DurationPolicy
+		requested    time.Duration
+		wantDuration time.Duration
+		wantErr      bool
+	}{
+		{name: "within bounds", requested: 24 * time.Hour, wantDuration: 24 * time.Hour},
+		{name: "clamped to minimum", requested: time.Minute, wantDuration: time.Hour},
+		{name: "clamped to maximum", requested: 90 * 24 * time.Hour, wantDuration: 30 * 24 * time.Hour},
+		{name: "rejected below minimum", policy: sampleissuerapi.DurationPolicyReject, requested: time.Minute, wantErr: true},
+		{name: "rejected above maximum", policy: sampleissuerapi.DurationPolicyReject, requested: 90 * 24 * time.Hour, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := newConstrainedSigningPolicy(&sampleissuerapi.IssuerSpec{
+				MinDuration:    &metav1.Duration{Duration: time.Hour},
+				MaxDuration:    &metav1.Duration{Duration: 30 * 24 * time.Hour},
+				DurationPolicy: tc.policy,
+			}, PermissiveSigningPolicy{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tmpl := &x509.Certificate{NotBefore: notBefore, NotAfter: notBefore.Add(tc.requested)}
+			err = policy.apply(tmpl)
+			if tc.wantErr {
+				var policyErr *controllers.PolicyError
+				if !errors.As(err, &policyErr) {
+					t.Fatalf("expected a PolicyError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := tmpl.NotAfter.Sub(tmpl.NotBefore); got != tc.wantDuration {
+				t.Errorf("want duration %v, got %v", tc.wantDuration, got)
+			}
+		})
+	}
+}
+
+func TestCASigningPolicyLimitPathLen(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxPathLen int
+		caCert     *x509.Certificate
+		want       int
+		wantErr    bool
+	}{
+		{name: "unlimited CA", maxPathLen: 2, caCert: &x509.Certificate{MaxPathLen: -1}, want: 2},
+		{name: "unset CA path length", maxPathLen: -1, caCert: &x509.Certificate{}, want: -1},
+		{name: "limited by CA", maxPathLen: -1, caCert: &x509.Certificate{MaxPathLen: 2}, want: 1},
+		{name: "lower than CA", maxPathLen: 0, caCert: &x509.Certificate{MaxPathLen: 2}, want: 0},
+		{name: "CA cannot sign CAs", maxPathLen: -1, caCert: &x509.Certificate{MaxPathLenZero: true}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := CASigningPolicy{MaxPathLen: tc.maxPathLen}.limitPathLen(tc.caCert)
+			if tc.wantErr {
+				var policyErr *controllers.PolicyError
+				if !errors.As(err, &policyErr) {
+					t.Fatalf("expected a PolicyError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.MaxPathLen != tc.want {
+				t.Errorf("want MaxPathLen %d, got %d", tc.want, p.MaxPathLen)
+			}
+		})
+	}
+}
+
+func TestConstrainedSigningPolicyExtensions(t *testing.T) {
+	mustStaple := pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}, Value: []byte{0x30, 0x03, 0x02, 0x01, 0x05}}
+	custom := pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Value: []byte{0x05, 0x00}}
+	keyUsage := pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 15}, Critical: true, Value: []byte{0x03, 0x02, 0x05, 0xa0}}
+	requested := []pkix.Extension{mustStaple, custom, keyUsage}
+
+	policy, err := newConstrainedSigningPolicy(&sampleissuerapi.IssuerSpec{
+		Policy: &sampleissuerapi.PolicySpec{AllowedExtensions: []string{"1.3.6.1.5.5.7.1.24"}},
+	}, PermissiveSigningPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{Extensions: requested}
+	if err := policy.apply(tmpl); err != nil {
+		t.Fatal(err)
+	}
+	if len(tmpl.ExtraExtensions) != 1 || !tmpl.ExtraExtensions[0].Id.Equal(mustStaple.Id) {
+		t.Errorf("expected only the Must-Staple extension, got %v", tmpl.ExtraExtensions)
+	}
+	if tmpl.Extensions != nil {
+		t.Errorf("expected Extensions to be reset, got %v", tmpl.Extensions)
+	}
+
+	// The key usage extension is parsed into the template, so it is not
+	// reported as dropped.
+	if got := droppedExtensions(requested, tmpl.ExtraExtensions); !slices.Equal(got, []string{"1.3.6.1.4.1.99999.1"}) {
+		t.Errorf("unexpected dropped extensions %v", got)
+	}
+}
+
+func TestConstrainedSigningPolicyUsages(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowedUsages []string
+		keyUsage      x509.KeyUsage
+		extKeyUsage   []x509.ExtKeyUsage
+		wantErr       bool
+	}{
+		{name: "default server auth", keyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment, extKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}},
+		{name: "default client auth", keyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment, extKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}},
+		{name: "default denies code signing", extKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}, wantErr: true},
+		{name: "client auth allowed", allowedUsages: []string{"digital signature", "client auth"}, keyUsage: x509.KeyUsageDigitalSignature, extKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}},
+		{name: "client auth denied", allowedUsages: []string{"digital signature", "server auth"}, keyUsage: x509.KeyUsageDigitalSignature, extKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, wantErr: true},
+		{name: "key usage denied", allowedUsages: []string{"client auth"}, keyUsage: x509.KeyUsageDigitalSignature, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := newConstrainedSigningPolicy(&sampleissuerapi.IssuerSpec{AllowedUsages: tc.allowedUsages}, PermissiveSigningPolicy{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tmpl := &x509.Certificate{KeyUsage: tc.keyUsage, ExtKeyUsage: tc.extKeyUsage}
+			err = policy.apply(tmpl)
+			if tc.wantErr {
+				var policyErr *controllers.PolicyError
+				if !errors.As(err, &policyErr) {
+					t.Fatalf("expected a PolicyError, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tmpl.KeyUsage != tc.keyUsage || !slices.Equal(tmpl.ExtKeyUsage, tc.extKeyUsage) {
+				t.Errorf("expected the requested usages to be kept, got %v %v", tmpl.KeyUsage, tmpl.ExtKeyUsage)
+			}
+		})
+	}
+}
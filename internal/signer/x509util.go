@@ -17,19 +17,83 @@ limitations under the License.
 package signer
 
 import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/pem"
 	"errors"
+	"fmt"
+	"slices"
 )
 
-func parseKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+// parseKey parses a PEM encoded private key. PKCS#1 RSA keys ("RSA PRIVATE
+// KEY"), SEC 1 EC keys ("EC PRIVATE KEY") and PKCS#8 RSA, ECDSA and Ed25519
+// keys ("PRIVATE KEY") are supported. PKCS#8 keys encrypted with PBES2
+// ("ENCRYPTED PRIVATE KEY") are decrypted with the passphrase.
+func parseKey(pemBytes []byte, passphrase []byte) (crypto.Signer, error) {
 	// extract PEM from request object
 	block, _ := pem.Decode(pemBytes)
-	if block == nil || block.Type != "RSA PRIVATE KEY" {
-		return nil, errors.New("PEM block type must be RSA PRIVATE KEY")
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key found")
 	}
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
+	if _, ok := block.Headers["Proc-Type"]; ok {
+		return nil, errors.New("legacy PEM encryption is not supported, use an encrypted PKCS#8 key instead")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		key, err = parseEncryptedPKCS8PrivateKey(block.Bytes, passphrase)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q, must be one of RSA PRIVATE KEY, EC PRIVATE KEY, PRIVATE KEY or ENCRYPTED PRIVATE KEY", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+func parseEncryptedPKCS8PrivateKey(der []byte, passphrase []byte) (any, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("private key is encrypted but no passphrase was provided")
+	}
+
+	decrypted, err := decryptPKCS8(der, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(decrypted)
+	if err != nil {
+		// The padding of the decrypted data can be valid by chance when the
+		// passphrase is wrong.
+		return nil, errIncorrectPassphrase
+	}
+	return key, nil
 }
 
 func parseCert(pemBytes []byte) (*x509.Certificate, error) {
@@ -40,3 +104,128 @@ func parseCert(pemBytes []byte) (*x509.Certificate, error) {
 	}
 	return x509.ParseCertificate(block.Bytes)
 }
+
+func parseCertChain(pemBytes []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, pemBytes = pem.Decode(pemBytes)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.New("PEM block type must be CERTIFICATE")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificates found")
+	}
+	return certs, nil
+}
+
+// appendMissingCerts appends the certificates which are not in certs yet.
+func appendMissingCerts(certs []*x509.Certificate, more ...*x509.Certificate) []*x509.Certificate {
+	for _, cert := range more {
+		if !slices.ContainsFunc(certs, cert.Equal) {
+			certs = append(certs, cert)
+		}
+	}
+	return certs
+}
+
+// encodeCerts returns the PEM encoding of certificates.
+func encodeCerts(certs ...*x509.Certificate) []byte {
+	var out []byte
+	for _, cert := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return out
+}
+
+// isSelfSigned reports whether a certificate is signed by its own key.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// splitRoot splits the chain of a CA certificate into its root and the
+// certificates between the CA certificate and the root. The root is nil if
+// the chain does not end with a self-signed certificate, and it is cert
+// itself if cert is self-signed.
+func splitRoot(cert *x509.Certificate, chain []*x509.Certificate) ([]*x509.Certificate, *x509.Certificate) {
+	if len(chain) == 0 {
+		if isSelfSigned(cert) {
+			return nil, cert
+		}
+		return nil, nil
+	}
+	if last := chain[len(chain)-1]; isSelfSigned(last) {
+		return chain[:len(chain)-1], last
+	}
+	return chain, nil
+}
+
+// keyMatchesCert returns an error if the public key of the private key does
+// not match the public key of the certificate.
+func keyMatchesCert(key crypto.Signer, cert *x509.Certificate) error {
+	pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return fmt.Errorf("unsupported private key type %T", key)
+	}
+	if !pub.Equal(cert.PublicKey) {
+		return errors.New("private key does not match the public key of the certificate")
+	}
+	return nil
+}
+
+// signatureAlgorithmForKey returns the signature algorithm used to sign
+// certificates with a CA key that has the given public key. The hash size of
+// ECDSA signatures matches the size of the curve.
+func signatureAlgorithmForKey(pub crypto.PublicKey) (x509.SignatureAlgorithm, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return x509.SHA256WithRSA, nil
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			return x509.ECDSAWithSHA256, nil
+		case elliptic.P384():
+			return x509.ECDSAWithSHA384, nil
+		case elliptic.P521():
+			return x509.ECDSAWithSHA512, nil
+		default:
+			return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported ECDSA curve %s", pub.Curve.Params().Name)
+		}
+	case ed25519.PublicKey:
+		return x509.PureEd25519, nil
+	default:
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// keyIdentifier returns the key identifier of a public key, which is the
+// leftmost 160 bits of the SHA-256 hash of the subjectPublicKey bit string as
+// described in RFC 7093, section 2. This is the same method that
+// x509.CreateCertificate uses for CA certificates.
+func keyIdentifier(pub crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	var spki struct {
+		Algorithm        pkix.AlgorithmIdentifier
+		SubjectPublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(der, &spki); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(spki.SubjectPublicKey.Bytes)
+	return sum[:20], nil
+}
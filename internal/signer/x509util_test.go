@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package signer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func mustGenerateKey(t *testing.T, keyType string) crypto.Signer {
+	t.Helper()
+
+	var key crypto.Signer
+	var err error
+	switch keyType {
+	case "rsa":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "p256":
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "p384":
+		key, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "p521":
+		key, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case "ed25519":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		t.Fatalf("unknown key type %q", keyType)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func mustEncodeKey(t *testing.T, key crypto.Signer, blockType string) []byte {
+	t.Helper()
+
+	var der []byte
+	var err error
+	switch blockType {
+	case "RSA PRIVATE KEY":
+		der = x509.MarshalPKCS1PrivateKey(key.(*rsa.PrivateKey))
+	case "EC PRIVATE KEY":
+		der, err = x509.MarshalECPrivateKey(key.(*ecdsa.PrivateKey))
+	default:
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func mustSelfSignedCA(t *testing.T, key crypto.Signer) *x509.Certificate {
+	t.Helper()
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestParseKeyAndSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyType   string
+		blockType string
+		wantAlg   x509.SignatureAlgorithm
+	}{
+		{name: "RSA PKCS#1", keyType: "rsa", blockType: "RSA PRIVATE KEY", wantAlg: x509.SHA256WithRSA},
+		{name: "RSA PKCS#8", keyType: "rsa", blockType: "PRIVATE KEY", wantAlg: x509.SHA256WithRSA},
+		{name: "ECDSA P-256 SEC 1", keyType: "p256", blockType: "EC PRIVATE KEY", wantAlg: x509.ECDSAWithSHA256},
+		{name: "ECDSA P-256 PKCS#8", keyType: "p256", blockType: "PRIVATE KEY", wantAlg: x509.ECDSAWithSHA256},
+		{name: "ECDSA P-384 SEC 1", keyType: "p384", blockType: "EC PRIVATE KEY", wantAlg: x509.ECDSAWithSHA384},
+		{name: "ECDSA P-521 PKCS#8", keyType: "p521", blockType: "PRIVATE KEY", wantAlg: x509.ECDSAWithSHA512},
+		{name: "Ed25519 PKCS#8", keyType: "ed25519", blockType: "PRIVATE KEY", wantAlg: x509.PureEd25519},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			generated := mustGenerateKey(t, tc.keyType)
+			caCert := mustSelfSignedCA(t, generated)
+
+			key, err := parseKey(mustEncodeKey(t, generated, tc.blockType), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := keyMatchesCert(key, caCert); err != nil {
+				t.Fatal(err)
+			}
+
+			ca := &CertificateAuthority{
+				Certificate: caCert,
+				PrivateKey:  key,
+			}
+			der, err := ca.Sign(testCertificateTemplate(t), PermissiveSigningPolicy{TTL: time.Hour})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cert.SignatureAlgorithm != tc.wantAlg {
+				t.Errorf("expected signature algorithm %v, got %v", tc.wantAlg, cert.SignatureAlgorithm)
+			}
+			if err := cert.CheckSignatureFrom(caCert); err != nil {
+				t.Errorf("invalid signature: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseKeyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyBytes []byte
+	}{
+		{name: "not PEM", keyBytes: []byte("not a key")},
+		{name: "unsupported block type", keyBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{0}})},
+		{name: "invalid PKCS#8", keyBytes: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte{0}})},
+		{name: "invalid SEC 1", keyBytes: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte{0}})},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := parseKey(tc.keyBytes, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestKeyMatchesCert(t *testing.T) {
+	for _, keyType := range []string{"rsa", "p256", "ed25519"} {
+		t.Run(keyType, func(t *testing.T) {
+			caCert := mustSelfSignedCA(t, mustGenerateKey(t, keyType))
+			if err := keyMatchesCert(mustGenerateKey(t, keyType), caCert); err == nil {
+				t.Error("expected an error for a key which does not match the certificate")
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	sampleissuerv1alpha1 "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var sampleclusterissuerlog = logf.Log.WithName("sampleclusterissuer-resource")
+
+// SetupSampleClusterIssuerWebhookWithManager registers the webhook for SampleClusterIssuer in the manager.
+func SetupSampleClusterIssuerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &sampleissuerv1alpha1.SampleClusterIssuer{}).
+		WithValidator(&SampleClusterIssuerCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-sample-issuer-example-com-v1alpha1-sampleclusterissuer,mutating=false,failurePolicy=fail,sideEffects=None,groups=sample-issuer.example.com,resources=sampleclusterissuers,verbs=create;update,versions=v1alpha1,name=vsampleclusterissuer-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SampleClusterIssuerCustomValidator validates SampleClusterIssuers when they
+// are created or updated, so that an invalid issuer is rejected by the API
+// server instead of failing when it is reconciled.
+type SampleClusterIssuerCustomValidator struct{}
+
+var _ admission.Validator[*sampleissuerv1alpha1.SampleClusterIssuer] = &SampleClusterIssuerCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *SampleClusterIssuerCustomValidator) ValidateCreate(_ context.Context, issuer *sampleissuerv1alpha1.SampleClusterIssuer) (admission.Warnings, error) {
+	sampleclusterissuerlog.V(1).Info("Validation for SampleClusterIssuer upon creation", "name", issuer.GetName())
+	return nil, validateSampleClusterIssuer(issuer)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *SampleClusterIssuerCustomValidator) ValidateUpdate(_ context.Context, _, issuer *sampleissuerv1alpha1.SampleClusterIssuer) (admission.Warnings, error) {
+	sampleclusterissuerlog.V(1).Info("Validation for SampleClusterIssuer upon update", "name", issuer.GetName())
+	return nil, validateSampleClusterIssuer(issuer)
+}
+
+// ValidateDelete implements admission.Validator. Deletions are not validated.
+func (v *SampleClusterIssuerCustomValidator) ValidateDelete(_ context.Context, _ *sampleissuerv1alpha1.SampleClusterIssuer) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateSampleClusterIssuer(issuer *sampleissuerv1alpha1.SampleClusterIssuer) error {
+	allErrs := validateClusterIssuerSpec(&issuer.Spec, field.NewPath("spec"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		sampleissuerv1alpha1.SchemeGroupVersion.WithKind("SampleClusterIssuer").GroupKind(),
+		issuer.Name, allErrs)
+}
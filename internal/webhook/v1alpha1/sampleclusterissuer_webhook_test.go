@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sampleissuerv1alpha1 "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+var _ = Describe("SampleClusterIssuer Webhook", func() {
+	var (
+		obj       *sampleissuerv1alpha1.SampleClusterIssuer
+		validator SampleClusterIssuerCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &sampleissuerv1alpha1.SampleClusterIssuer{
+			ObjectMeta: metav1.ObjectMeta{Name: "sampleclusterissuer-webhook"},
+			Spec: sampleissuerv1alpha1.ClusterIssuerSpec{
+				IssuerSpec: sampleissuerv1alpha1.IssuerSpec{
+					URL:            "https://sample-issuer.example.com/api/v1",
+					AuthSecretName: "sampleclusterissuer-credentials",
+				},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			},
+		}
+		validator = SampleClusterIssuerCustomValidator{}
+	})
+
+	Context("When creating or updating SampleClusterIssuer under Validating Webhook", func() {
+		It("Should admit a valid cluster issuer", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, obj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a cluster issuer with an invalid issuer spec", func() {
+			obj.Spec.URL = "http://sample-issuer.example.com/api/v1"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+			Expect(err.Error()).To(ContainSubstring("spec.url"))
+		})
+
+		It("Should deny a cluster issuer with an invalid namespace selector", func() {
+			obj.Spec.NamespaceSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Equals"}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+			Expect(err.Error()).To(ContainSubstring("spec.namespaceSelector"))
+		})
+
+		It("Should reject an invalid cluster issuer when it is applied", func() {
+			obj.Spec.AuthSecretName = ""
+			err := k8sClient.Create(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+
+			obj.Spec.AuthSecretName = "sampleclusterissuer-credentials"
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+		})
+	})
+})
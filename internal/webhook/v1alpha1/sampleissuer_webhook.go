@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	sampleissuerv1alpha1 "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var sampleissuerlog = logf.Log.WithName("sampleissuer-resource")
+
+// SetupSampleIssuerWebhookWithManager registers the webhook for SampleIssuer in the manager.
+func SetupSampleIssuerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &sampleissuerv1alpha1.SampleIssuer{}).
+		WithValidator(&SampleIssuerCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-sample-issuer-example-com-v1alpha1-sampleissuer,mutating=false,failurePolicy=fail,sideEffects=None,groups=sample-issuer.example.com,resources=sampleissuers,verbs=create;update,versions=v1alpha1,name=vsampleissuer-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// SampleIssuerCustomValidator validates SampleIssuers when they are created or
+// updated, so that an invalid issuer is rejected by the API server instead of
+// failing when it is reconciled.
+type SampleIssuerCustomValidator struct{}
+
+var _ admission.Validator[*sampleissuerv1alpha1.SampleIssuer] = &SampleIssuerCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *SampleIssuerCustomValidator) ValidateCreate(_ context.Context, issuer *sampleissuerv1alpha1.SampleIssuer) (admission.Warnings, error) {
+	sampleissuerlog.V(1).Info("Validation for SampleIssuer upon creation", "name", issuer.GetName())
+	return nil, validateSampleIssuer(issuer)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *SampleIssuerCustomValidator) ValidateUpdate(_ context.Context, _, issuer *sampleissuerv1alpha1.SampleIssuer) (admission.Warnings, error) {
+	sampleissuerlog.V(1).Info("Validation for SampleIssuer upon update", "name", issuer.GetName())
+	return nil, validateSampleIssuer(issuer)
+}
+
+// ValidateDelete implements admission.Validator. Deletions are not validated.
+func (v *SampleIssuerCustomValidator) ValidateDelete(_ context.Context, _ *sampleissuerv1alpha1.SampleIssuer) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateSampleIssuer(issuer *sampleissuerv1alpha1.SampleIssuer) error {
+	allErrs := validateIssuerSpec(&issuer.Spec, field.NewPath("spec"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(
+		sampleissuerv1alpha1.SchemeGroupVersion.WithKind("SampleIssuer").GroupKind(),
+		issuer.Name, allErrs)
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	sampleissuerv1alpha1 "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	sampleissuerv1beta1 "github.com/cert-manager/sample-external-issuer/api/v1beta1"
+)
+
+var _ = Describe("SampleIssuer Webhook", func() {
+	var (
+		obj       *sampleissuerv1alpha1.SampleIssuer
+		validator SampleIssuerCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &sampleissuerv1alpha1.SampleIssuer{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sampleissuer-webhook"},
+			Spec: sampleissuerv1alpha1.IssuerSpec{
+				URL:            "https://sample-issuer.example.com/api/v1",
+				AuthSecretName: "sampleissuer-credentials",
+			},
+		}
+		validator = SampleIssuerCustomValidator{}
+	})
+
+	Context("When creating or updating SampleIssuer under Validating Webhook", func() {
+		It("Should admit a valid issuer", func() {
+			obj.Spec.MinDuration = &metav1.Duration{Duration: time.Hour}
+			obj.Spec.MaxDuration = &metav1.Duration{Duration: 24 * time.Hour}
+			obj.Spec.DefaultDuration = &metav1.Duration{Duration: 12 * time.Hour}
+			obj.Spec.AllowedUsages = []string{"digital signature", "client auth"}
+			obj.Spec.Policy = &sampleissuerv1alpha1.PolicySpec{
+				AllowedDNSNames:   []string{"example.com", "*.example.com", ".example.org"},
+				AllowedIPRanges:   []string{"10.0.0.0/8"},
+				AllowedExtensions: []string{"1.3.6.1.5.5.7.1.24"},
+				CELRules:          []sampleissuerv1alpha1.CELRule{{Expression: "certificate.duration < duration('24h')"}},
+			}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			Expect(validator.ValidateUpdate(ctx, obj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		DescribeTable("Should deny an invalid issuer",
+			func(mutate func(*sampleissuerv1alpha1.IssuerSpec), field string) {
+				mutate(&obj.Spec)
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+				Expect(err.Error()).To(ContainSubstring(field))
+			},
+			Entry("without a URL", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.URL = ""
+			}, "spec.url"),
+			Entry("with an http URL", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.URL = "http://sample-issuer.example.com/api/v1"
+			}, "spec.url"),
+			Entry("with a URL without a host", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.URL = "https:///api/v1"
+			}, "spec.url"),
+			Entry("with a URL which cannot be parsed", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.URL = "https://sample-issuer.example.com:port/"
+			}, "spec.url"),
+			Entry("without an auth Secret", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.AuthSecretName = ""
+			}, "spec.authSecretName"),
+			Entry("with a passphrase Secret without a key", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.PrivateKeyPassphraseSecretRef = &sampleissuerv1alpha1.SecretKeySelector{Name: "passphrase"}
+			}, "spec.privateKeyPassphraseSecretRef.key"),
+			Entry("with a CA bundle which is not PEM encoded", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.TLS = &sampleissuerv1alpha1.TLSSpec{CABundle: []byte("ca")}
+			}, "spec.tls.caBundle"),
+			Entry("with a minimum duration greater than the maximum duration", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.MinDuration = &metav1.Duration{Duration: 48 * time.Hour}
+				spec.MaxDuration = &metav1.Duration{Duration: 24 * time.Hour}
+			}, "spec.minDuration"),
+			Entry("with a negative default duration", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.DefaultDuration = &metav1.Duration{Duration: -time.Hour}
+			}, "spec.defaultDuration"),
+			Entry("with a default duration less than the minimum duration", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.MinDuration = &metav1.Duration{Duration: time.Hour}
+				spec.DefaultDuration = &metav1.Duration{Duration: time.Minute}
+			}, "spec.defaultDuration"),
+			Entry("with a default duration greater than the maximum duration", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.MaxDuration = &metav1.Duration{Duration: time.Hour}
+				spec.DefaultDuration = &metav1.Duration{Duration: 2 * time.Hour}
+			}, "spec.defaultDuration"),
+			Entry("with an unknown allowed usage", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.AllowedUsages = []string{"server auth", "serverAuth"}
+			}, "spec.allowedUsages[1]"),
+			Entry("with an invalid allowed DNS name pattern", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Policy = &sampleissuerv1alpha1.PolicySpec{AllowedDNSNames: []string{"www.*.example.com"}}
+			}, "spec.policy.allowedDNSNames[0]"),
+			Entry("with an invalid allowed URI host pattern", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Policy = &sampleissuerv1alpha1.PolicySpec{AllowedURIHosts: []string{"*example.com"}}
+			}, "spec.policy.allowedURIHosts[0]"),
+			Entry("with an invalid allowed email domain pattern", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Policy = &sampleissuerv1alpha1.PolicySpec{AllowedEmailDomains: []string{"*"}}
+			}, "spec.policy.allowedEmailDomains[0]"),
+			Entry("with an allowed extension which is not an OID", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Policy = &sampleissuerv1alpha1.PolicySpec{AllowedExtensions: []string{"tls-feature"}}
+			}, "spec.policy.allowedExtensions[0]"),
+			Entry("with an allowed extension which is set by the issuer", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Policy = &sampleissuerv1alpha1.PolicySpec{AllowedExtensions: []string{"1.3.6.1.5.5.7.1.24", "2.5.29.17"}}
+			}, "spec.policy.allowedExtensions[1]"),
+			Entry("with an invalid allowed IP range", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Policy = &sampleissuerv1alpha1.PolicySpec{AllowedIPRanges: []string{"10.0.0.0/8", "10.0.0.1"}}
+			}, "spec.policy.allowedIPRanges[1]"),
+			Entry("with an invalid name constraint IP range", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.CAIssuance = &sampleissuerv1alpha1.CAIssuanceSpec{
+					NameConstraints: &sampleissuerv1alpha1.NameConstraintsSpec{ExcludedIPRanges: []string{"192.168.0.0/33"}},
+				}
+			}, "spec.caIssuance.nameConstraints.excludedIPRanges[0]"),
+			Entry("with a CEL rule which does not compile", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Policy = &sampleissuerv1alpha1.PolicySpec{
+					CELRules: []sampleissuerv1alpha1.CELRule{{Expression: "certificate.commonName =="}},
+				}
+			}, "spec.policy.celRules"),
+			Entry("with a CEL rule which does not evaluate to a bool", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Policy = &sampleissuerv1alpha1.PolicySpec{
+					CELRules: []sampleissuerv1alpha1.CELRule{{Expression: "certificate.commonName"}},
+				}
+			}, "spec.policy.celRules"),
+			Entry("with a minimum backoff greater than the maximum backoff", func(spec *sampleissuerv1alpha1.IssuerSpec) {
+				spec.Retry = &sampleissuerv1alpha1.RetrySpec{
+					MinBackoff: &metav1.Duration{Duration: time.Minute},
+					MaxBackoff: &metav1.Duration{Duration: time.Second},
+				}
+			}, "spec.retry.minBackoff"),
+		)
+
+		It("Should reject an invalid issuer when it is applied", func() {
+			By("creating an issuer with an http URL")
+			obj.Spec.URL = "http://sample-issuer.example.com/api/v1"
+			err := k8sClient.Create(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+
+			By("creating a valid issuer")
+			obj.Spec.URL = "https://sample-issuer.example.com/api/v1"
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+			})
+
+			By("updating the issuer with an invalid CEL rule")
+			obj.Spec.Policy = &sampleissuerv1alpha1.PolicySpec{
+				CELRules: []sampleissuerv1alpha1.CELRule{{Expression: "certificate.commonName"}},
+			}
+			err = k8sClient.Update(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+		})
+	})
+
+	Context("When reading and writing SampleIssuer under Conversion Webhook", func() {
+		It("Should convert an issuer between v1alpha1 and v1beta1 when it is applied", func() {
+			By("creating a v1alpha1 issuer")
+			obj.Spec.MinDuration = &metav1.Duration{Duration: time.Hour}
+			obj.Spec.Policy = &sampleissuerv1alpha1.PolicySpec{AllowedDNSNames: []string{".example.com"}}
+			Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, obj)).To(Succeed())
+			})
+
+			By("reading the issuer as v1beta1")
+			beta := &sampleissuerv1beta1.SampleIssuer{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), beta)).To(Succeed())
+			Expect(beta.Spec.Backend.URL).To(Equal(obj.Spec.URL))
+			Expect(beta.Spec.Auth.SecretName).To(Equal(obj.Spec.AuthSecretName))
+			Expect(beta.Spec.Policy.Duration.Min).To(Equal(obj.Spec.MinDuration))
+			Expect(beta.Spec.Policy.Names.AllowedDNSNames).To(Equal(obj.Spec.Policy.AllowedDNSNames))
+
+			By("updating the issuer as v1beta1")
+			beta.Spec.Auth.SecretName = "sampleissuer-credentials-2"
+			Expect(k8sClient.Update(ctx, beta)).To(Succeed())
+
+			By("reading the issuer as v1alpha1")
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+			Expect(obj.Spec.AuthSecretName).To(Equal("sampleissuer-credentials-2"))
+			Expect(obj.Spec.MinDuration).To(Equal(beta.Spec.Policy.Duration.Min))
+		})
+
+		It("Should reject an invalid v1beta1 issuer when it is applied", func() {
+			beta := &sampleissuerv1beta1.SampleIssuer{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "sampleissuer-webhook-v1beta1"},
+				Spec: sampleissuerv1beta1.IssuerSpec{
+					Backend: sampleissuerv1beta1.BackendSpec{URL: "http://sample-issuer.example.com/api/v1"},
+					Auth:    sampleissuerv1beta1.AuthSpec{SecretName: "sampleissuer-credentials"},
+				},
+			}
+			err := k8sClient.Create(ctx, beta)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue(), "expected an Invalid error, got %v", err)
+		})
+	})
+})
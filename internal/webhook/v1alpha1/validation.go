@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	sampleissuerv1alpha1 "github.com/cert-manager/sample-external-issuer/api/v1alpha1"
+	"github.com/cert-manager/sample-external-issuer/internal/celpolicy"
+	"github.com/cert-manager/sample-external-issuer/internal/x509policy"
+)
+
+// validateIssuerSpec returns the errors of the spec of a SampleIssuer or
+// SampleClusterIssuer which would otherwise only be found when the issuer is
+// reconciled.
+func validateIssuerSpec(spec *sampleissuerv1alpha1.IssuerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	allErrs = append(allErrs, validateURL(spec.URL, fldPath.Child("url"))...)
+
+	if spec.AuthSecretName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("authSecretName"), "must be the name of the Secret with the credentials of the issuer"))
+	}
+
+	if ref := spec.PrivateKeyPassphraseSecretRef; ref != nil {
+		refPath := fldPath.Child("privateKeyPassphraseSecretRef")
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+		}
+		if ref.Key == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("key"), ""))
+		}
+	}
+
+	if spec.TLS != nil && len(spec.TLS.CABundle) > 0 {
+		allErrs = append(allErrs, validateCABundle(spec.TLS.CABundle, fldPath.Child("tls", "caBundle"))...)
+	}
+
+	allErrs = append(allErrs, validatePositiveDuration(spec.DefaultDuration, fldPath.Child("defaultDuration"))...)
+	allErrs = append(allErrs, validatePositiveDuration(spec.MinDuration, fldPath.Child("minDuration"))...)
+	allErrs = append(allErrs, validatePositiveDuration(spec.MaxDuration, fldPath.Child("maxDuration"))...)
+	if spec.MinDuration != nil && spec.MaxDuration != nil && spec.MinDuration.Duration > spec.MaxDuration.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minDuration"), spec.MinDuration.Duration.String(), "must not be greater than maxDuration"))
+	}
+	if spec.DefaultDuration != nil {
+		if spec.MinDuration != nil && spec.DefaultDuration.Duration < spec.MinDuration.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaultDuration"), spec.DefaultDuration.Duration.String(), "must not be less than minDuration"))
+		}
+		if spec.MaxDuration != nil && spec.DefaultDuration.Duration > spec.MaxDuration.Duration {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaultDuration"), spec.DefaultDuration.Duration.String(), "must not be greater than maxDuration"))
+		}
+	}
+
+	for i, usage := range spec.AllowedUsages {
+		if _, _, err := x509policy.KeyUsagesFromStrings(x509policy.KeyUsagesFrom([]string{usage})); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedUsages").Index(i), usage, "must be a key usage, for example \"digital signature\" or \"client auth\""))
+		}
+	}
+
+	if spec.Policy != nil {
+		allErrs = append(allErrs, validatePolicy(spec.Policy, fldPath.Child("policy"))...)
+	}
+
+	if spec.CAIssuance != nil && spec.CAIssuance.NameConstraints != nil {
+		constraintsPath := fldPath.Child("caIssuance", "nameConstraints")
+		allErrs = append(allErrs, validateCIDRs(spec.CAIssuance.NameConstraints.PermittedIPRanges, constraintsPath.Child("permittedIPRanges"))...)
+		allErrs = append(allErrs, validateCIDRs(spec.CAIssuance.NameConstraints.ExcludedIPRanges, constraintsPath.Child("excludedIPRanges"))...)
+	}
+
+	if spec.Retry != nil {
+		retryPath := fldPath.Child("retry")
+		allErrs = append(allErrs, validatePositiveDuration(spec.Retry.MaxDuration, retryPath.Child("maxDuration"))...)
+		allErrs = append(allErrs, validatePositiveDuration(spec.Retry.MinBackoff, retryPath.Child("minBackoff"))...)
+		allErrs = append(allErrs, validatePositiveDuration(spec.Retry.MaxBackoff, retryPath.Child("maxBackoff"))...)
+		if spec.Retry.MinBackoff != nil && spec.Retry.MaxBackoff != nil && spec.Retry.MinBackoff.Duration > spec.Retry.MaxBackoff.Duration {
+			allErrs = append(allErrs, field.Invalid(retryPath.Child("minBackoff"), spec.Retry.MinBackoff.Duration.String(), "must not be greater than maxBackoff"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateClusterIssuerSpec returns the errors of the spec of a
+// SampleClusterIssuer.
+func validateClusterIssuerSpec(spec *sampleissuerv1alpha1.ClusterIssuerSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := validateIssuerSpec(&spec.IssuerSpec, fldPath)
+
+	if spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaceSelector"), spec.NamespaceSelector, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateURL checks that the URL of the signing service is an absolute HTTPS
+// URL. The credentials of the issuer are sent to it.
+func validateURL(rawURL string, fldPath *field.Path) field.ErrorList {
+	if rawURL == "" {
+		return field.ErrorList{field.Required(fldPath, "must be the URL of the signing service")}
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, rawURL, err.Error())}
+	}
+	if u.Scheme != "https" {
+		return field.ErrorList{field.Invalid(fldPath, rawURL, "must be an https URL")}
+	}
+	if u.Host == "" {
+		return field.ErrorList{field.Invalid(fldPath, rawURL, "must have a host")}
+	}
+	return nil
+}
+
+// validateCABundle checks that a CA bundle only contains PEM encoded
+// certificates.
+func validateCABundle(caBundle []byte, fldPath *field.Path) field.ErrorList {
+	var found bool
+	for rest := caBundle; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, fmt.Sprintf("PEM block type must be CERTIFICATE, got %q", block.Type))}
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, err.Error())}
+		}
+		found = true
+	}
+	if !found {
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, "must contain PEM encoded certificates")}
+	}
+	return nil
+}
+
+// validatePositiveDuration checks that an optional duration is greater than
+// zero.
+func validatePositiveDuration(duration *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	if duration != nil && duration.Duration <= 0 {
+		return field.ErrorList{field.Invalid(fldPath, duration.Duration.String(), "must be greater than zero")}
+	}
+	return nil
+}
+
+// validatePolicy checks that the patterns, CIDR ranges and OIDs of a policy
+// can be parsed and that its CEL rules compile.
+func validatePolicy(policy *sampleissuerv1alpha1.PolicySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateDNSPatterns(policy.AllowedDNSNames, fldPath.Child("allowedDNSNames"))...)
+	allErrs = append(allErrs, validateDNSPatterns(policy.AllowedURIHosts, fldPath.Child("allowedURIHosts"))...)
+	allErrs = append(allErrs, validateDNSPatterns(policy.AllowedEmailDomains, fldPath.Child("allowedEmailDomains"))...)
+	allErrs = append(allErrs, validateCIDRs(policy.AllowedIPRanges, fldPath.Child("allowedIPRanges"))...)
+
+	for i, oid := range policy.AllowedExtensions {
+		if _, err := x509policy.ParseExtensionOID(oid); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("allowedExtensions").Index(i), oid, err.Error()))
+		}
+	}
+
+	if len(policy.CELRules) > 0 {
+		if _, err := celpolicy.Compile(policy.CELRules); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("celRules"), field.OmitValueType{}, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateDNSPatterns checks that every pattern is a DNS name, a wildcard or
+// a suffix.
+func validateDNSPatterns(patterns []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, pattern := range patterns {
+		if err := x509policy.ValidateDNSPattern(pattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), pattern, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateCIDRs checks that every range is a valid CIDR range.
+func validateCIDRs(ranges []string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, cidr := range ranges {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), cidr, "must be a CIDR range, for example 10.0.0.0/8"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	sampleissuerv1beta1 "github.com/cert-manager/sample-external-issuer/api/v1beta1"
+)
+
+// SetupSampleIssuerWebhookWithManager registers the conversion webhook for
+// SampleIssuer in the manager. v1beta1 is the hub version, so the other versions
+// of SampleIssuer are converted to and from v1beta1.
+func SetupSampleIssuerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &sampleissuerv1beta1.SampleIssuer{}).
+		Complete()
+}
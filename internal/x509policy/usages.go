@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509policy
+
+import (
+	"crypto/x509"
+	"fmt"
+	"slices"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+)
+
+// KeyUsage is a usage of a certificate. The values are the usage strings of
+// cert-manager (cmapi.KeyUsage) and the Kubernetes certificates API
+// (certificatesv1.KeyUsage), which are converted with KeyUsagesFrom.
+type KeyUsage string
+
+const (
+	UsageSigning           KeyUsage = "signing"
+	UsageDigitalSignature  KeyUsage = "digital signature"
+	UsageContentCommitment KeyUsage = "content commitment"
+	UsageKeyEncipherment   KeyUsage = "key encipherment"
+	UsageKeyAgreement      KeyUsage = "key agreement"
+	UsageDataEncipherment  KeyUsage = "data encipherment"
+	UsageCertSign          KeyUsage = "cert sign"
+	UsageCRLSign           KeyUsage = "crl sign"
+	UsageEncipherOnly      KeyUsage = "encipher only"
+	UsageDecipherOnly      KeyUsage = "decipher only"
+	UsageAny               KeyUsage = "any"
+	UsageServerAuth        KeyUsage = "server auth"
+	UsageClientAuth        KeyUsage = "client auth"
+	UsageCodeSigning       KeyUsage = "code signing"
+	UsageEmailProtection   KeyUsage = "email protection"
+	UsageSMIME             KeyUsage = "s/mime"
+	UsageIPsecEndSystem    KeyUsage = "ipsec end system"
+	UsageIPsecTunnel       KeyUsage = "ipsec tunnel"
+	UsageIPsecUser         KeyUsage = "ipsec user"
+	UsageTimestamping      KeyUsage = "timestamping"
+	UsageOCSPSigning       KeyUsage = "ocsp signing"
+	UsageMicrosoftSGC      KeyUsage = "microsoft sgc"
+	UsageNetscapeSGC       KeyUsage = "netscape sgc"
+
+	// The Microsoft code signing usages have no equivalent in cert-manager or
+	// the certificates API. They can only be allowed by an issuer, so that
+	// every x509.ExtKeyUsage has a usage string.
+	UsageMicrosoftCommercialCodeSigning KeyUsage = "microsoft commercial code signing"
+	UsageMicrosoftKernelCodeSigning     KeyUsage = "microsoft kernel code signing"
+)
+
+var keyUsageDict = map[KeyUsage]x509.KeyUsage{
+	UsageSigning:           x509.KeyUsageDigitalSignature,
+	UsageDigitalSignature:  x509.KeyUsageDigitalSignature,
+	UsageContentCommitment: x509.KeyUsageContentCommitment,
+	UsageKeyEncipherment:   x509.KeyUsageKeyEncipherment,
+	UsageKeyAgreement:      x509.KeyUsageKeyAgreement,
+	UsageDataEncipherment:  x509.KeyUsageDataEncipherment,
+	UsageCertSign:          x509.KeyUsageCertSign,
+	UsageCRLSign:           x509.KeyUsageCRLSign,
+	UsageEncipherOnly:      x509.KeyUsageEncipherOnly,
+	UsageDecipherOnly:      x509.KeyUsageDecipherOnly,
+}
+
+var extKeyUsageDict = map[KeyUsage]x509.ExtKeyUsage{
+	UsageAny:                            x509.ExtKeyUsageAny,
+	UsageServerAuth:                     x509.ExtKeyUsageServerAuth,
+	UsageClientAuth:                     x509.ExtKeyUsageClientAuth,
+	UsageCodeSigning:                    x509.ExtKeyUsageCodeSigning,
+	UsageEmailProtection:                x509.ExtKeyUsageEmailProtection,
+	UsageSMIME:                          x509.ExtKeyUsageEmailProtection,
+	UsageIPsecEndSystem:                 x509.ExtKeyUsageIPSECEndSystem,
+	UsageIPsecTunnel:                    x509.ExtKeyUsageIPSECTunnel,
+	UsageIPsecUser:                      x509.ExtKeyUsageIPSECUser,
+	UsageTimestamping:                   x509.ExtKeyUsageTimeStamping,
+	UsageOCSPSigning:                    x509.ExtKeyUsageOCSPSigning,
+	UsageMicrosoftSGC:                   x509.ExtKeyUsageMicrosoftServerGatedCrypto,
+	UsageNetscapeSGC:                    x509.ExtKeyUsageNetscapeServerGatedCrypto,
+	UsageMicrosoftCommercialCodeSigning: x509.ExtKeyUsageMicrosoftCommercialCodeSigning,
+	UsageMicrosoftKernelCodeSigning:     x509.ExtKeyUsageMicrosoftKernelCodeSigning,
+}
+
+// KeyUsagesFrom converts the usages of an issuer, of a cert-manager
+// CertificateRequest or of a Kubernetes CertificateSigningRequest to
+// KeyUsages.
+func KeyUsagesFrom[T string | cmapi.KeyUsage | certificatesv1.KeyUsage](usages []T) []KeyUsage {
+	converted := make([]KeyUsage, 0, len(usages))
+	for _, usage := range usages {
+		converted = append(converted, KeyUsage(usage))
+	}
+	return converted
+}
+
+// KeyUsagesFromStrings translates a slice of usage strings to
+// x509.KeyUsage and x509.ExtKeyUsage types. The extended key usages are
+// sorted.
+func KeyUsagesFromStrings(usages []KeyUsage) (x509.KeyUsage, []x509.ExtKeyUsage, error) {
+	var keyUsage x509.KeyUsage
+	var extKeyUsages []x509.ExtKeyUsage
+	var unrecognized []KeyUsage
+	for _, usage := range usages {
+		if val, ok := keyUsageDict[usage]; ok {
+			keyUsage |= val
+		} else if val, ok := extKeyUsageDict[usage]; ok {
+			if !slices.Contains(extKeyUsages, val) {
+				extKeyUsages = append(extKeyUsages, val)
+			}
+		} else {
+			unrecognized = append(unrecognized, usage)
+		}
+	}
+
+	if len(unrecognized) > 0 {
+		return 0, nil, fmt.Errorf("unrecognized usage values: %q", unrecognized)
+	}
+
+	slices.Sort(extKeyUsages)
+	return keyUsage, extKeyUsages, nil
+}
+
+// keyUsageOrder and extKeyUsageOrder list one usage string for every
+// x509.KeyUsage and x509.ExtKeyUsage in keyUsageDict and extKeyUsageDict, in
+// the order in which they are returned by KeyUsagesToStrings.
+var keyUsageOrder = []KeyUsage{
+	UsageDigitalSignature,
+	UsageContentCommitment,
+	UsageKeyEncipherment,
+	UsageKeyAgreement,
+	UsageDataEncipherment,
+	UsageCertSign,
+	UsageCRLSign,
+	UsageEncipherOnly,
+	UsageDecipherOnly,
+}
+
+var extKeyUsageOrder = []KeyUsage{
+	UsageAny,
+	UsageServerAuth,
+	UsageClientAuth,
+	UsageCodeSigning,
+	UsageEmailProtection,
+	UsageIPsecEndSystem,
+	UsageIPsecTunnel,
+	UsageIPsecUser,
+	UsageTimestamping,
+	UsageOCSPSigning,
+	UsageMicrosoftSGC,
+	UsageNetscapeSGC,
+	UsageMicrosoftCommercialCodeSigning,
+	UsageMicrosoftKernelCodeSigning,
+}
+
+// KeyUsagesToStrings is the inverse of KeyUsagesFromStrings.
+func KeyUsagesToStrings(keyUsage x509.KeyUsage, extKeyUsages []x509.ExtKeyUsage) []KeyUsage {
+	var usages []KeyUsage
+	for _, usage := range keyUsageOrder {
+		if keyUsage&keyUsageDict[usage] != 0 {
+			usages = append(usages, usage)
+		}
+	}
+	for _, usage := range extKeyUsageOrder {
+		if slices.Contains(extKeyUsages, extKeyUsageDict[usage]) {
+			usages = append(usages, usage)
+		}
+	}
+	return usages
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509policy
+
+import (
+	"crypto/x509"
+	"slices"
+	"testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+)
+
+var cmapiUsages = []cmapi.KeyUsage{
+	cmapi.UsageSigning,
+	cmapi.UsageDigitalSignature,
+	cmapi.UsageContentCommitment,
+	cmapi.UsageKeyEncipherment,
+	cmapi.UsageKeyAgreement,
+	cmapi.UsageDataEncipherment,
+	cmapi.UsageCertSign,
+	cmapi.UsageCRLSign,
+	cmapi.UsageEncipherOnly,
+	cmapi.UsageDecipherOnly,
+	cmapi.UsageAny,
+	cmapi.UsageServerAuth,
+	cmapi.UsageClientAuth,
+	cmapi.UsageCodeSigning,
+	cmapi.UsageEmailProtection,
+	cmapi.UsageSMIME,
+	cmapi.UsageIPsecEndSystem,
+	cmapi.UsageIPsecTunnel,
+	cmapi.UsageIPsecUser,
+	cmapi.UsageTimestamping,
+	cmapi.UsageOCSPSigning,
+	cmapi.UsageMicrosoftSGC,
+	cmapi.UsageNetscapeSGC,
+}
+
+var certificatesv1Usages = []certificatesv1.KeyUsage{
+	certificatesv1.UsageSigning,
+	certificatesv1.UsageDigitalSignature,
+	certificatesv1.UsageContentCommitment,
+	certificatesv1.UsageKeyEncipherment,
+	certificatesv1.UsageKeyAgreement,
+	certificatesv1.UsageDataEncipherment,
+	certificatesv1.UsageCertSign,
+	certificatesv1.UsageCRLSign,
+	certificatesv1.UsageEncipherOnly,
+	certificatesv1.UsageDecipherOnly,
+	certificatesv1.UsageAny,
+	certificatesv1.UsageServerAuth,
+	certificatesv1.UsageClientAuth,
+	certificatesv1.UsageCodeSigning,
+	certificatesv1.UsageEmailProtection,
+	certificatesv1.UsageSMIME,
+	certificatesv1.UsageIPsecEndSystem,
+	certificatesv1.UsageIPsecTunnel,
+	certificatesv1.UsageIPsecUser,
+	certificatesv1.UsageTimestamping,
+	certificatesv1.UsageOCSPSigning,
+	certificatesv1.UsageMicrosoftSGC,
+	certificatesv1.UsageNetscapeSGC,
+}
+
+// TestKeyUsagesMatchCertManager checks that every cert-manager usage
+// translates to the same x509 usage as in cert-manager's own tables.
+func TestKeyUsagesMatchCertManager(t *testing.T) {
+	for _, usage := range cmapiUsages {
+		t.Run(string(usage), func(t *testing.T) {
+			keyUsage, extKeyUsages, err := KeyUsagesFromStrings(KeyUsagesFrom([]cmapi.KeyUsage{usage}))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wantKeyUsage x509.KeyUsage
+			var wantExtKeyUsages []x509.ExtKeyUsage
+			if ku, ok := apiutil.KeyUsageType(usage); ok {
+				wantKeyUsage = ku
+			} else if eku, ok := apiutil.ExtKeyUsageType(usage); ok {
+				wantExtKeyUsages = []x509.ExtKeyUsage{eku}
+			} else {
+				t.Fatalf("usage %q is unknown to cert-manager", usage)
+			}
+
+			if keyUsage != wantKeyUsage {
+				t.Errorf("key usage: got %v, want %v", keyUsage, wantKeyUsage)
+			}
+			if !slices.Equal(extKeyUsages, wantExtKeyUsages) {
+				t.Errorf("extended key usages: got %v, want %v", extKeyUsages, wantExtKeyUsages)
+			}
+		})
+	}
+}
+
+// TestKeyUsagesCertificatesV1 checks that the certificates/v1 usages are
+// the same strings as the cert-manager usages.
+func TestKeyUsagesCertificatesV1(t *testing.T) {
+	got := KeyUsagesFrom(certificatesv1Usages)
+	want := KeyUsagesFrom(cmapiUsages)
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, _, err := KeyUsagesFromStrings(got); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestKeyUsagesRoundTrip checks that every x509 usage known to cert-manager
+// survives KeyUsagesToStrings followed by KeyUsagesFromStrings, and that
+// KeyUsagesToStrings only returns usages which cert-manager understands.
+func TestKeyUsagesRoundTrip(t *testing.T) {
+	for _, usage := range cmapiUsages {
+		t.Run(string(usage), func(t *testing.T) {
+			keyUsage, _ := apiutil.KeyUsageType(usage)
+			var extKeyUsages []x509.ExtKeyUsage
+			if eku, ok := apiutil.ExtKeyUsageType(usage); ok {
+				extKeyUsages = []x509.ExtKeyUsage{eku}
+			}
+
+			usages := KeyUsagesToStrings(keyUsage, extKeyUsages)
+			for _, u := range usages {
+				_, isKeyUsage := apiutil.KeyUsageType(cmapi.KeyUsage(u))
+				_, isExtKeyUsage := apiutil.ExtKeyUsageType(cmapi.KeyUsage(u))
+				if !isKeyUsage && !isExtKeyUsage {
+					t.Errorf("usage %q is unknown to cert-manager", u)
+				}
+			}
+
+			gotKeyUsage, gotExtKeyUsages, err := KeyUsagesFromStrings(usages)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotKeyUsage != keyUsage {
+				t.Errorf("key usage: got %v, want %v", gotKeyUsage, keyUsage)
+			}
+			if !slices.Equal(gotExtKeyUsages, extKeyUsages) {
+				t.Errorf("extended key usages: got %v, want %v", gotExtKeyUsages, extKeyUsages)
+			}
+		})
+	}
+}
+
+// TestKeyUsagesAllExtKeyUsages checks that every extended key usage of the
+// x509 package has a usage string, including those unknown to cert-manager.
+func TestKeyUsagesAllExtKeyUsages(t *testing.T) {
+	for eku := x509.ExtKeyUsageAny; eku <= x509.ExtKeyUsageMicrosoftKernelCodeSigning; eku++ {
+		usages := KeyUsagesToStrings(0, []x509.ExtKeyUsage{eku})
+		if len(usages) != 1 {
+			t.Errorf("extended key usage %v: got usages %q", eku, usages)
+			continue
+		}
+		_, got, err := KeyUsagesFromStrings(usages)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, []x509.ExtKeyUsage{eku}) {
+			t.Errorf("extended key usage %v: got %v", eku, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package x509policy parses the parts of the spec of an issuer which are
+// needed by both the signers and the validating webhooks: key usages, DNS
+// name patterns and the OIDs of extensions.
+package x509policy
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// issuerExtensions are the extensions which x509.CreateCertificate sets from
+// the fields of the template. They cannot be allowed, because they would
+// override the fields which are set or validated by the signing policies.
+var issuerExtensions = []asn1.ObjectIdentifier{
+	{2, 5, 29, 14},              // subject key identifier
+	{2, 5, 29, 15},              // key usage
+	{2, 5, 29, 17},              // subject alternative name
+	{2, 5, 29, 19},              // basic constraints
+	{2, 5, 29, 30},              // name constraints
+	{2, 5, 29, 31},              // CRL distribution points
+	{2, 5, 29, 32},              // certificate policies
+	{2, 5, 29, 35},              // authority key identifier
+	{2, 5, 29, 37},              // extended key usage
+	{1, 3, 6, 1, 5, 5, 7, 1, 1}, // authority information access
+}
+
+// ParseExtensionOID parses the OID of an extension which may be allowed by an
+// issuer policy.
+func ParseExtensionOID(s string) (x509.OID, error) {
+	oid, err := x509.ParseOID(s)
+	if err != nil {
+		return x509.OID{}, err
+	}
+	if slices.ContainsFunc(issuerExtensions, oid.EqualASN1OID) {
+		return x509.OID{}, errors.New("the extension is set by the issuer")
+	}
+	return oid, nil
+}
+
+// ValidateDNSPattern checks that a pattern is a DNS name, a wildcard
+// ("*.example.com") or a suffix (".example.com").
+func ValidateDNSPattern(pattern string) error {
+	name := strings.TrimPrefix(strings.TrimPrefix(pattern, "*"), ".")
+	if name == "" || strings.Contains(name, "*") {
+		return errors.New(`must be a DNS name, optionally prefixed with "*." or "."`)
+	}
+	if strings.HasPrefix(pattern, "*") && !strings.HasPrefix(pattern, "*.") {
+		return errors.New(`a wildcard must be the whole leftmost label`)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package x509policy
+
+import "testing"
+
+func TestValidateDNSPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{pattern: "example.com"},
+		{pattern: "*.example.com"},
+		{pattern: ".example.com"},
+		{pattern: "", wantErr: true},
+		{pattern: "*", wantErr: true},
+		{pattern: "*example.com", wantErr: true},
+		{pattern: "www.*.example.com", wantErr: true},
+	}
+	for _, tc := range tests {
+		if err := ValidateDNSPattern(tc.pattern); (err != nil) != tc.wantErr {
+			t.Errorf("ValidateDNSPattern(%q): wantErr %v, got %v", tc.pattern, tc.wantErr, err)
+		}
+	}
+}
+
+func TestParseExtensionOID(t *testing.T) {
+	tests := []struct {
+		oid     string
+		wantErr bool
+	}{
+		{oid: "1.3.6.1.5.5.7.1.24"},
+		{oid: "not an OID", wantErr: true},
+		{oid: "2.5.29.17", wantErr: true},
+	}
+	for _, tc := range tests {
+		if _, err := ParseExtensionOID(tc.oid); (err != nil) != tc.wantErr {
+			t.Errorf("ParseExtensionOID(%q): wantErr %v, got %v", tc.oid, tc.wantErr, err)
+		}
+	}
+}